@@ -1,11 +1,15 @@
 use async_trait::async_trait;
 use std::error::Error;
 
-use domain::{dtos::create_product_dto::CreateProductDto, entities::product_entity::ProductEntity};
+use domain::{
+    dtos::{create_product_dto::CreateProductDto, update_product_dto::UpdateProductDto},
+    entities::product_entity::ProductEntity,
+};
 
 #[async_trait]
 pub trait IProductRepository: Send + Sync {
     async fn create(&self, dto: CreateProductDto) -> Result<ProductEntity, Box<dyn Error>>;
+    async fn update(&self, dto: UpdateProductDto) -> Result<Option<ProductEntity>, Box<dyn Error>>;
     async fn get_product_by_id(&self, id: String) -> Result<Option<ProductEntity>, Box<dyn Error>>;
     async fn get_products_by_type(
         &self,
@@ -16,4 +20,5 @@ pub trait IProductRepository: Send + Sync {
         limit: u32,
         offset: u32,
     ) -> Result<Vec<ProductEntity>, Box<dyn Error>>;
+    async fn count_products(&self) -> Result<u64, Box<dyn Error>>;
 }
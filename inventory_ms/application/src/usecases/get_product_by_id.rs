@@ -28,7 +28,9 @@ impl IGetProductByIdUseCase for GetProductByIdUseCase {
 #[cfg(test)]
 mod test {
     use super::*;
-    use domain::dtos::create_product_dto::CreateProductDto;
+    use domain::dtos::{
+        create_product_dto::CreateProductDto, update_product_dto::UpdateProductDto,
+    };
     use mockall::*;
     use std::io::{Error, ErrorKind};
 
@@ -94,6 +96,9 @@ mod test {
             async fn create(&self, dto: CreateProductDto) -> Result<ProductEntity, Box<dyn std::error::Error>> {
                 todo!()
             }
+            async fn update(&self, dto: UpdateProductDto) -> Result<Option<ProductEntity>, Box<dyn std::error::Error>> {
+                todo!()
+            }
             async fn get_products_by_type(&self, product_type: String) -> Result<Vec<ProductEntity>, Box<dyn std::error::Error>> {
                 todo!()
             }
@@ -104,6 +109,9 @@ mod test {
             ) -> Result<Vec<ProductEntity>, Box<dyn std::error::Error>> {
                 todo!()
             }
+            async fn count_products(&self) -> Result<u64, Box<dyn std::error::Error>> {
+                todo!()
+            }
         }
     }
 }
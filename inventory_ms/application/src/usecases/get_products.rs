@@ -18,7 +18,14 @@ impl GetProductsUseCase {
 
 #[async_trait]
 impl IGetProductsUseCase for GetProductsUseCase {
-    async fn perform(&self, limit: u32, offset: u32) -> Result<Vec<ProductEntity>, Box<dyn Error>> {
-        self.repo.get_products(limit, offset).await
+    async fn perform(
+        &self,
+        limit: u32,
+        offset: u32,
+    ) -> Result<(Vec<ProductEntity>, u64), Box<dyn Error>> {
+        let products = self.repo.get_products(limit, offset).await?;
+        let total = self.repo.count_products().await?;
+
+        Ok((products, total))
     }
 }
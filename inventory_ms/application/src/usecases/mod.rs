@@ -2,3 +2,4 @@ pub mod create_product;
 pub mod get_product_by_id;
 pub mod get_products;
 pub mod get_products_by_type;
+pub mod update_product;
@@ -0,0 +1,27 @@
+use async_trait::async_trait;
+use std::{error::Error, sync::Arc};
+
+use crate::interfaces::i_product_repository::IProductRepository;
+use domain::{
+    dtos::update_product_dto::UpdateProductDto, entities::product_entity::ProductEntity,
+    usecases::i_update_product::IUpdateProductUseCase,
+};
+pub struct UpdateProductUseCase {
+    repo: Arc<dyn IProductRepository>,
+}
+
+impl UpdateProductUseCase {
+    pub fn new(repo: Arc<dyn IProductRepository>) -> impl IUpdateProductUseCase {
+        UpdateProductUseCase { repo }
+    }
+}
+
+#[async_trait]
+impl IUpdateProductUseCase for UpdateProductUseCase {
+    async fn perform(
+        &self,
+        dto: UpdateProductDto,
+    ) -> Result<Option<ProductEntity>, Box<dyn Error>> {
+        self.repo.update(dto).await
+    }
+}
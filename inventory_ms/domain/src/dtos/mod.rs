@@ -1 +1,2 @@
 pub mod create_product_dto;
+pub mod update_product_dto;
@@ -0,0 +1,9 @@
+/// Fields left as None keep their stored value.
+#[derive(Debug)]
+pub struct UpdateProductDto {
+    pub id: String,
+    pub tag: Option<String>,
+    pub title: Option<String>,
+    pub subtitle: Option<String>,
+    pub amount_in_stock: Option<i64>,
+}
@@ -6,7 +6,12 @@ use crate::entities::product_entity::ProductEntity;
 
 #[async_trait]
 pub trait IGetProductsUseCase: Send + Sync {
-    async fn perform(&self, limit: u32, offset: u32) -> Result<Vec<ProductEntity>, Box<dyn Error>>;
+    /// Returns the requested page together with the total number of products.
+    async fn perform(
+        &self,
+        limit: u32,
+        offset: u32,
+    ) -> Result<(Vec<ProductEntity>, u64), Box<dyn Error>>;
 }
 impl Debug for dyn IGetProductsUseCase {
     fn fmt(&self, f: &mut core::fmt::Formatter<'_>) -> core::fmt::Result {
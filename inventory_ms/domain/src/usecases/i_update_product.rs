@@ -0,0 +1,17 @@
+use async_trait::async_trait;
+use core::fmt::Debug;
+use std::error::Error;
+
+use crate::{dtos::update_product_dto::UpdateProductDto, entities::product_entity::ProductEntity};
+
+#[async_trait]
+pub trait IUpdateProductUseCase: Send + Sync {
+    async fn perform(&self, dto: UpdateProductDto)
+        -> Result<Option<ProductEntity>, Box<dyn Error>>;
+}
+
+impl Debug for dyn IUpdateProductUseCase {
+    fn fmt(&self, f: &mut core::fmt::Formatter<'_>) -> core::fmt::Result {
+        write!(f, "IUpdateProductUseCase")
+    }
+}
@@ -2,3 +2,4 @@ pub mod i_create_product;
 pub mod i_get_product_by_id;
 pub mod i_get_products;
 pub mod i_get_products_by_type;
+pub mod i_update_product;
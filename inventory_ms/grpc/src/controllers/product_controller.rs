@@ -14,6 +14,7 @@ use crate::{
 use domain::usecases::{
     i_create_product::ICreateProductUseCase, i_get_product_by_id::IGetProductByIdUseCase,
     i_get_products::IGetProductsUseCase, i_get_products_by_type::IGetProductsByTypeUseCase,
+    i_update_product::IUpdateProductUseCase,
 };
 #[derive(Debug)]
 pub struct ProductController {
@@ -21,6 +22,7 @@ pub struct ProductController {
     create_product_use_case: Arc<dyn ICreateProductUseCase>,
     get_products_by_tag_use_case: Arc<dyn IGetProductsByTypeUseCase>,
     get_products_use_case: Arc<dyn IGetProductsUseCase>,
+    update_product_use_case: Arc<dyn IUpdateProductUseCase>,
     telemetry: Arc<Telemetry>,
 }
 
@@ -30,6 +32,7 @@ impl ProductController {
         create_product_use_case: Arc<dyn ICreateProductUseCase>,
         get_products_by_tag_use_case: Arc<dyn IGetProductsByTypeUseCase>,
         get_products_use_case: Arc<dyn IGetProductsUseCase>,
+        update_product_use_case: Arc<dyn IUpdateProductUseCase>,
         telemetry: Arc<Telemetry>,
     ) -> ProductController {
         ProductController {
@@ -37,6 +40,7 @@ impl ProductController {
             create_product_use_case,
             get_products_by_tag_use_case,
             get_products_use_case,
+            update_product_use_case,
             telemetry,
         }
     }
@@ -111,12 +115,9 @@ impl Inventory for ProductController {
             .await;
 
         match result {
-            Ok(products) => {
-                if products.len() == 0 {
-                    return Ok(Response::new(ProductsResponse::default()));
-                }
-
+            Ok((products, total)) => {
                 let mut response = ProductsResponse::default();
+                response.total = total as i64;
                 for product in products {
                     response
                         .value
@@ -146,23 +147,23 @@ impl Inventory for ProductController {
         }
     }
 
+    #[instrument(name = "gRPC updateProduct")]
     async fn update_product(
         &self,
-        _request: Request<UpdateProductRequest>,
+        request: Request<UpdateProductRequest>,
     ) -> Result<Response<ProductResponse>, Status> {
-        Ok(Response::new(ProductResponse {
-            id: String::new(),
-            product_category: String::new(),
-            tag: String::new(),
-            title: String::new(),
-            subtitle: String::new(),
-            authors: vec![],
-            amount_in_stock: 10,
-            created_at: String::new(),
-            updated_at: String::new(),
-            num_pages: 10,
-            tags: vec![],
-        }))
+        self.telemetry.grpc_set_span_parent(&request);
+
+        let result = self
+            .update_product_use_case
+            .perform(ProductModel::update_request_to_dto(request.into_inner()))
+            .instrument(tracing::Span::current())
+            .await;
+        match result {
+            Ok(Some(product)) => Ok(Response::new(ProductModel::entity_to_response(product))),
+            Ok(None) => Err(Status::not_found("Not Found")),
+            Err(err) => Err(Status::internal(format!("{:?}", err))),
+        }
     }
 }
 
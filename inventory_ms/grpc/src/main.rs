@@ -6,6 +6,7 @@ use tonic::transport::Server;
 
 use application::usecases::{
     create_product::CreateProductUseCase, get_product_by_id::GetProductByIdUseCase,
+    update_product::UpdateProductUseCase,
 };
 use infra::{
     database, environments, repositories::product_repository::ProductRepository,
@@ -19,6 +20,7 @@ mod controllers;
 mod inventory;
 mod middlewares;
 mod models;
+mod tls;
 
 #[tokio::main]
 async fn main() -> Result<(), Box<dyn std::error::Error>> {
@@ -42,17 +44,25 @@ async fn main() -> Result<(), Box<dyn std::error::Error>> {
 
     let get_products_use_case = Arc::new(GetProductsUseCase::new(product_repository.clone()));
 
+    let update_product_use_case = Arc::new(UpdateProductUseCase::new(product_repository.clone()));
+
     let product_controller = ProductController::new(
         get_product_by_id_use_case,
         create_product_use_case,
         get_products_by_tag_use_case,
         get_products_use_case,
+        update_product_use_case,
         Arc::new(telemetry_app),
     );
 
     info!("Server listening on {}", addr);
 
-    Server::builder()
+    let mut server = Server::builder();
+    if let Some(tls_config) = tls::server_tls_config()? {
+        server = server.tls_config(tls_config)?;
+    }
+
+    server
         .add_service(InventoryServer::new(product_controller))
         .serve(addr)
         .await?;
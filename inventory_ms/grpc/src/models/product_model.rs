@@ -1,6 +1,9 @@
-use domain::{dtos::create_product_dto::CreateProductDto, entities::product_entity::ProductEntity};
+use domain::{
+    dtos::{create_product_dto::CreateProductDto, update_product_dto::UpdateProductDto},
+    entities::product_entity::ProductEntity,
+};
 
-use crate::inventory::{CreateProductRequest, ProductResponse};
+use crate::inventory::{CreateProductRequest, ProductResponse, UpdateProductRequest};
 pub struct ProductModel;
 
 impl ProductModel {
@@ -17,6 +20,16 @@ impl ProductModel {
         }
     }
 
+    pub fn update_request_to_dto(request: UpdateProductRequest) -> UpdateProductDto {
+        UpdateProductDto {
+            id: request.id,
+            tag: request.tag,
+            title: request.title,
+            subtitle: request.subtitle,
+            amount_in_stock: request.amount_in_stock,
+        }
+    }
+
     pub fn entity_to_response(entity: ProductEntity) -> ProductResponse {
         ProductResponse {
             id: entity.id,
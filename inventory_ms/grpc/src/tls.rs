@@ -0,0 +1,24 @@
+use std::{env, error::Error, fs};
+use tonic::transport::{Certificate, Identity, ServerTlsConfig};
+
+/// TLS is served when INVENTORY_TLS_CERT_FILE is set. Clients must then present
+/// a certificate signed by INVENTORY_TLS_CLIENT_CA_FILE, when that is set too.
+pub fn server_tls_config() -> Result<Option<ServerTlsConfig>, Box<dyn Error>> {
+    let cert_file = match env::var("INVENTORY_TLS_CERT_FILE") {
+        Ok(file) if !file.is_empty() => file,
+        _ => return Ok(None),
+    };
+    let key_file = env::var("INVENTORY_TLS_KEY_FILE")?;
+
+    let identity = Identity::from_pem(fs::read(cert_file)?, fs::read(key_file)?);
+    let mut config = ServerTlsConfig::new().identity(identity);
+
+    match env::var("INVENTORY_TLS_CLIENT_CA_FILE") {
+        Ok(ca_file) if !ca_file.is_empty() => {
+            config = config.client_ca_root(Certificate::from_pem(fs::read(ca_file)?));
+        }
+        _ => {}
+    }
+
+    Ok(Some(config))
+}
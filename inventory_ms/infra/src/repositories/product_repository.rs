@@ -1,8 +1,8 @@
 use async_trait::async_trait;
 use futures::stream::TryStreamExt;
 use mongodb::{
-    bson::{doc, DateTime},
-    options::FindOptions,
+    bson::{doc, DateTime, Document},
+    options::{FindOneAndUpdateOptions, FindOptions, ReturnDocument},
 };
 use std::error::Error;
 use tracing::instrument;
@@ -11,7 +11,10 @@ use uuid::Uuid;
 use tracing_futures::Instrument;
 
 use application::interfaces::i_product_repository::IProductRepository;
-use domain::{dtos::create_product_dto::CreateProductDto, entities::product_entity::ProductEntity};
+use domain::{
+    dtos::{create_product_dto::CreateProductDto, update_product_dto::UpdateProductDto},
+    entities::product_entity::ProductEntity,
+};
 
 use crate::database::{connection::DbConnection, documents::product_documents::ProductDocument};
 
@@ -26,6 +29,11 @@ impl ProductRepository {
     }
 }
 
+// The product type is stored as the document's product_category.
+fn products_by_type_filter(product_type: String) -> Document {
+    doc! { "product_category": product_type }
+}
+
 #[async_trait]
 impl IProductRepository for ProductRepository {
     #[instrument(name = "MONGO SELECT PRODUCT BY ID")]
@@ -54,9 +62,8 @@ impl IProductRepository for ProductRepository {
             .connection
             .get_collection::<ProductDocument>(&self.connection.inventory_collection_name);
 
-        let filter = doc! { "type": product_type };
         let mut cursor = collection
-            .find(filter, None)
+            .find(products_by_type_filter(product_type), None)
             .instrument(tracing::Span::current())
             .await?;
 
@@ -110,6 +117,40 @@ impl IProductRepository for ProductRepository {
         }
     }
 
+    #[instrument(name = "MONGO UPDATE PRODUCT")]
+    async fn update(&self, dto: UpdateProductDto) -> Result<Option<ProductEntity>, Box<dyn Error>> {
+        let collection = self
+            .connection
+            .get_collection::<ProductDocument>(&self.connection.inventory_collection_name);
+
+        let mut changes = Document::new();
+        if let Some(tag) = dto.tag {
+            changes.insert("tag", tag);
+        }
+        if let Some(title) = dto.title {
+            changes.insert("title", title);
+        }
+        if let Some(subtitle) = dto.subtitle {
+            changes.insert("subtitle", subtitle);
+        }
+        if let Some(amount_in_stock) = dto.amount_in_stock {
+            changes.insert("amount_in_stock", amount_in_stock);
+        }
+        changes.insert("updated_at", DateTime::now());
+
+        let options = FindOneAndUpdateOptions::builder()
+            .return_document(ReturnDocument::After)
+            .build();
+        match collection
+            .find_one_and_update(doc! { "id": dto.id }, doc! { "$set": changes }, options)
+            .instrument(tracing::Span::current())
+            .await?
+        {
+            None => Ok(None),
+            Some(document) => Ok(Some(document.to_entity())),
+        }
+    }
+
     #[instrument(name = "MONGO GET PRODUCTS WITH PAGINATION")]
     async fn get_products(
         &self,
@@ -122,7 +163,7 @@ impl IProductRepository for ProductRepository {
 
         let options = FindOptions::builder()
             .limit(limit as i64)
-            .batch_size(offset)
+            .skip(offset as u64)
             .build();
         let mut cursor = collection
             .find(None, options)
@@ -136,12 +177,84 @@ impl IProductRepository for ProductRepository {
 
         Ok(products)
     }
+
+    #[instrument(name = "MONGO COUNT PRODUCTS")]
+    async fn count_products(&self) -> Result<u64, Box<dyn Error>> {
+        let collection = self
+            .connection
+            .get_collection::<ProductDocument>(&self.connection.inventory_collection_name);
+
+        let total = collection
+            .count_documents(None, None)
+            .instrument(tracing::Span::current())
+            .await?;
+
+        Ok(total)
+    }
 }
 
 #[cfg(test)]
 mod test {
     use super::*;
-    use mongodb::Client;
+    use mongodb::{bson::to_document, Client};
+    use std::env;
+
+    fn product_document(product_category: &str) -> ProductDocument {
+        ProductDocument {
+            id: Uuid::new_v4().to_hyphenated().to_string(),
+            product_category: String::from(product_category),
+            tag: String::from("tag"),
+            title: String::from("title"),
+            subtitle: String::from("subtitle"),
+            authors: vec![String::from("author")],
+            amount_in_stock: 1,
+            created_at: DateTime::now(),
+            updated_at: DateTime::now(),
+            num_pages: 100,
+            tags: vec![],
+        }
+    }
+
+    #[test]
+    fn should_filter_products_by_the_stored_category() {
+        let document = to_document(&product_document("book")).unwrap();
+
+        for (key, value) in products_by_type_filter(String::from("book")) {
+            assert_eq!(document.get(&key), Some(&value));
+        }
+    }
+
+    // Runs against the database in MONGO_CONNECTION_URI, when set.
+    #[tokio::test]
+    async fn should_return_the_products_of_a_type() -> Result<(), Box<dyn Error>> {
+        let mongo_connection_uri = match env::var("MONGO_CONNECTION_URI") {
+            Ok(uri) => uri,
+            Err(_) => return Ok(()),
+        };
+
+        let db_connection = DbConnection {
+            client: Client::with_uri_str(mongo_connection_uri).await?,
+            app_name: String::from("inventory_ms_test"),
+            db_name: String::from("inventory_ms_test"),
+            inventory_collection_name: Uuid::new_v4().to_hyphenated().to_string(),
+        };
+        let collection = db_connection
+            .get_collection::<ProductDocument>(&db_connection.inventory_collection_name);
+        let document = product_document("book");
+        collection
+            .insert_many(vec![document.clone(), product_document("comic")], None)
+            .await?;
+
+        let sut = ProductRepository::new(db_connection);
+        let products = sut.get_products_by_type(String::from("book")).await;
+        collection.drop(None).await?;
+
+        let products = products?;
+        assert_eq!(products.len(), 1);
+        assert_eq!(products[0].id, document.id);
+
+        Ok(())
+    }
 
     #[tokio::test]
     async fn should_execute_method_with_connection_error() -> mongodb::error::Result<()> {
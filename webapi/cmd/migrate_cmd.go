@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/environments"
+	"webapi/pkg/infra/logger"
+)
+
+const migrateUsage = "usage: webapi migrate up | down [steps] | redo | status"
+
+// Migrate runs `webapi migrate <subcommand>` against the database configured
+// for the current GO_ENV.
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	if err := environments.Configure(); err != nil {
+		return err
+	}
+
+	dbConnection, err := dbConnectionFromEnv()
+	if err != nil {
+		return err
+	}
+	defer dbConnection.Close()
+
+	migrator := database.NewMigrator(logger.NewLogger(), dbConnection, os.Getenv("DB_DRIVER"))
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errors.New(migrateUsage)
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("reverted", reverted)
+		return err
+
+	case "redo":
+		applied, err := migrator.Redo(ctx)
+		printMigrations("redone", applied)
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+func printMigrations(action string, migrations []database.Migration) {
+	if len(migrations) == 0 {
+		fmt.Printf("nothing %s\n", action)
+		return
+	}
+
+	for _, migration := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, migration.Version, migration.Name)
+	}
+}
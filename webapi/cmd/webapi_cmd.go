@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+
+	"webapi/pkg/infra/database"
 	"webapi/pkg/infra/environments"
 )
 
@@ -10,6 +15,17 @@ func WebApi() error {
 	}
 
 	container := NewContainer()
+	defer container.dbConnection.Close()
+	defer container.statements.Close()
+	defer container.tokenManager.Close()
+	defer container.inventoryConnection.Close()
+	if container.redisClient != nil {
+		defer container.redisClient.Close()
+	}
+
+	if err := prepareSchema(container.migrator); err != nil {
+		return err
+	}
 
 	// Server setup
 	container.httpServer.Setup()
@@ -19,7 +35,11 @@ func WebApi() error {
 
 	// Router register
 	container.usersRoutes.Register(container.httpServer)
+	container.profileRoutes.Register(container.httpServer)
 	container.authenticationRoutes.Register(container.httpServer)
+	container.passwordResetRoutes.Register(container.httpServer)
+	container.mfaRoutes.Register(container.httpServer)
+	container.jwksRoutes.Register(container.httpServer)
 	container.inventoryRoutes.Register(container.httpServer)
 	container.purchaseRoutes.Register(container.httpServer)
 
@@ -28,3 +48,33 @@ func WebApi() error {
 	}
 	return nil
 }
+
+// prepareSchema migrates the embedded database on start, it belongs to this
+// process and may well be in memory. Postgres is migrated on its own.
+func prepareSchema(migrator database.IMigrator) error {
+	if os.Getenv("DB_DRIVER") == database.SqliteDriver {
+		_, err := migrator.Up(context.Background())
+		return err
+	}
+
+	return ensureSchemaIsMigrated(migrator)
+}
+
+// ensureSchemaIsMigrated refuses to serve requests against an outdated schema
+// when DB_REQUIRE_MIGRATED is true, instead of failing on the first query.
+func ensureSchemaIsMigrated(migrator database.IMigrator) error {
+	if os.Getenv("DB_REQUIRE_MIGRATED") != "true" {
+		return nil
+	}
+
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind by %d migration(s), run `webapi migrate up` first", len(pending))
+	}
+
+	return nil
+}
@@ -1,32 +1,50 @@
 package cmd
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 	"webapi/pkg/app/interfaces"
 	appUseCases "webapi/pkg/app/usecases"
+	"webapi/pkg/infra/cache"
 	"webapi/pkg/infra/database"
 	grpcClients "webapi/pkg/infra/grpc_clients"
 	"webapi/pkg/infra/hasher"
 	httpServer "webapi/pkg/infra/http_server"
 	"webapi/pkg/infra/logger"
+	loginAttemptStore "webapi/pkg/infra/login_attempt_store"
 	msgBroker "webapi/pkg/infra/message_broker"
 	"webapi/pkg/infra/repositories"
 	"webapi/pkg/infra/telemetry"
 	tokenManager "webapi/pkg/infra/token_manager"
+	"webapi/pkg/infra/totp"
 	"webapi/pkg/infra/validator"
 	"webapi/pkg/interfaces/http/handlers"
 	"webapi/pkg/interfaces/http/middlewares"
 	"webapi/pkg/interfaces/http/presenters"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc"
 )
 
 type webApiContainer struct {
 	logger        interfaces.ILogger
 	httpServer    httpServer.IHttpServer
 	messageBroker interfaces.IMessageBroker
+	migrator      database.IMigrator
+	dbConnection  *sql.DB
+	statements    database.IStatementCache
+
+	tokenManager        interfaces.ITokenManager
+	inventoryConnection *grpc.ClientConn
+	redisClient         *redis.Client
 
 	usersRoutes          presenters.IUsersRoutes
+	profileRoutes        presenters.IProfileRoutes
 	authenticationRoutes presenters.ISessionRoutes
+	passwordResetRoutes  presenters.IPasswordResetRoutes
+	mfaRoutes            presenters.IMfaRoutes
+	jwksRoutes           presenters.IJwksRoutes
 	inventoryRoutes      presenters.IInventoryRoutes
 	purchaseRoutes       presenters.IPurchaseRoutes
 
@@ -34,18 +52,7 @@ type webApiContainer struct {
 }
 
 func NewContainer() webApiContainer {
-	connectionString := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-	)
-	dbConnection, err := database.GetConnection(
-		os.Getenv("DB_DRIVER"),
-		connectionString,
-	)
+	dbConnection, err := dbConnectionFromEnv()
 	if err != nil {
 		panic(err)
 	}
@@ -55,26 +62,89 @@ func NewContainer() webApiContainer {
 	httpServer := httpServer.NewHttpServer(logger)
 	telemetryApp := telemetry.NewTelemetry()
 	messageBroker := msgBroker.NewMessageBroker(telemetryApp)
+	migrator := database.NewMigrator(logger, dbConnection, os.Getenv("DB_DRIVER"))
+	transactionManager := database.NewTransactionManager(logger, dbConnection, telemetryApp)
+	statements := database.NewStatementCache(dbConnection)
+
+	redisClient, err := cache.NewRedisClient()
+	if err != nil {
+		panic(err)
+	}
+
+	userCache, err := cache.NewUserCache(logger, redisClient)
+	if err != nil {
+		panic(err)
+	}
 
-	userRepository := repositories.NewUserRepository(logger, dbConnection, telemetryApp)
+	userRepository := repositories.NewCachedUserRepository(newUserRepository(logger, statements, telemetryApp), userCache)
+	sessionRepository := repositories.NewSessionRepository(logger, dbConnection, telemetryApp)
+	tokenDenylistRepository := repositories.NewCachedTokenDenylistRepository(repositories.NewTokenDenylistRepository(logger, dbConnection, telemetryApp), userCache)
+	roleRepository := repositories.NewRoleRepository(logger, dbConnection, telemetryApp)
+	passwordResetRepository := repositories.NewPasswordResetRepository(logger, dbConnection, telemetryApp)
+	mfaRepository := repositories.NewMfaRepository(logger, dbConnection, telemetryApp)
+	loginAttemptStore := newLoginAttemptStore(logger, dbConnection, telemetryApp)
 	hasher := hasher.NewHahser(logger)
 	accessTokenManager := tokenManager.NewTokenManager(logger)
-	createUserUseCase := appUseCases.NewCreateUserUseCase(userRepository, hasher, accessTokenManager)
-	usersHandler := handlers.NewUsersHandler(logger, createUserUseCase, validatoR)
-	usersRoutes := presenters.NewUsersRoutes(logger, usersHandler)
+	totpGenerator := totp.NewTotp()
 
-	authenticationUserUseCase := appUseCases.NewSessionUseCase(userRepository, hasher, accessTokenManager, logger)
-	authenticationHandler := handlers.NewSessionHandler(logger, authenticationUserUseCase, validatoR)
-	authenticationRoutes := presenters.NewSessionRoutes(logger, authenticationHandler)
-
-	validationTokenUseCase := appUseCases.NewValidatinTokenUseCase(userRepository, accessTokenManager)
+	validationTokenUseCase := appUseCases.NewValidatinTokenUseCase(userRepository, tokenDenylistRepository, accessTokenManager)
 	authenticationMiddleware := middlewares.NewAuthMiddleware(validationTokenUseCase)
+	authorizationMiddleware := middlewares.NewAuthorizationMiddleware(logger)
+
+	createUserUseCase := appUseCases.NewCreateUserUseCase(userRepository, sessionRepository, roleRepository, transactionManager, hasher, accessTokenManager, messageBroker, logger)
+	revokeUserSessionsUseCase := appUseCases.NewRevokeUserSessionsUseCase(sessionRepository, tokenDenylistRepository)
+	verifyEmailUseCase := appUseCases.NewVerifyEmailUseCase(userRepository, accessTokenManager, tokenDenylistRepository)
+	requestEmailVerificationUseCase := appUseCases.NewRequestEmailVerificationUseCase(userRepository, accessTokenManager, messageBroker)
+	usersHandler := handlers.NewUsersHandler(logger, createUserUseCase, revokeUserSessionsUseCase, verifyEmailUseCase, requestEmailVerificationUseCase, validatoR)
+	usersRoutes := presenters.NewUsersRoutes(logger, authenticationMiddleware, usersHandler)
+
+	getUserProfileUseCase := appUseCases.NewGetUserProfileUseCase(userRepository)
+	updateUserProfileUseCase := appUseCases.NewUpdateUserProfileUseCase(userRepository, accessTokenManager, messageBroker, logger)
+	changePasswordUseCase := appUseCases.NewChangePasswordUseCase(userRepository, sessionRepository, tokenDenylistRepository, loginAttemptStore, hasher, logger)
+	deleteUserUseCase := appUseCases.NewDeleteUserUseCase(userRepository, sessionRepository, tokenDenylistRepository)
+	profileHandler := handlers.NewProfileHandler(logger, getUserProfileUseCase, updateUserProfileUseCase, changePasswordUseCase, deleteUserUseCase, validatoR)
+	profileRoutes := presenters.NewProfileRoutes(logger, authenticationMiddleware, profileHandler)
+
+	authenticationUserUseCase := appUseCases.NewSessionUseCase(userRepository, sessionRepository, roleRepository, mfaRepository, loginAttemptStore, hasher, accessTokenManager, logger)
+	refreshSessionUseCase := appUseCases.NewRefreshSessionUseCase(userRepository, sessionRepository, roleRepository, transactionManager, accessTokenManager, logger)
+	logoutUseCase := appUseCases.NewLogoutUseCase(sessionRepository, tokenDenylistRepository)
+	authenticationHandler := handlers.NewSessionHandler(logger, authenticationUserUseCase, refreshSessionUseCase, logoutUseCase, validatoR)
+	authenticationRoutes := presenters.NewSessionRoutes(logger, authenticationMiddleware, authenticationHandler)
+
+	requestPasswordResetUseCase := appUseCases.NewRequestPasswordResetUseCase(userRepository, passwordResetRepository, accessTokenManager, messageBroker)
+	confirmPasswordResetUseCase := appUseCases.NewConfirmPasswordResetUseCase(userRepository, passwordResetRepository, sessionRepository, tokenDenylistRepository, hasher, accessTokenManager)
+	passwordResetHandler := handlers.NewPasswordResetHandler(logger, requestPasswordResetUseCase, confirmPasswordResetUseCase, validatoR)
+	passwordResetRoutes := presenters.NewPasswordResetRoutes(logger, passwordResetHandler)
+
+	enrollMfaUseCase := appUseCases.NewEnrollMfaUseCase(userRepository, mfaRepository, totpGenerator)
+	confirmMfaUseCase := appUseCases.NewConfirmMfaUseCase(mfaRepository, loginAttemptStore, totpGenerator, accessTokenManager, logger)
+	disableMfaUseCase := appUseCases.NewDisableMfaUseCase(mfaRepository, loginAttemptStore, totpGenerator, accessTokenManager, logger)
+	verifyMfaUseCase := appUseCases.NewVerifyMfaUseCase(mfaRepository, sessionRepository, roleRepository, loginAttemptStore, totpGenerator, accessTokenManager, logger)
+	mfaHandler := handlers.NewMfaHandler(logger, enrollMfaUseCase, confirmMfaUseCase, disableMfaUseCase, verifyMfaUseCase, validatoR)
+	mfaRoutes := presenters.NewMfaRoutes(logger, authenticationMiddleware, mfaHandler)
+
+	getJsonWebKeysUseCase := appUseCases.NewGetJsonWebKeysUseCase(accessTokenManager)
+	jwksHandler := handlers.NewJwksHandler(logger, getJsonWebKeysUseCase)
+	jwksRoutes := presenters.NewJwksRoutes(logger, jwksHandler)
+
+	inventoryConnection, err := grpcClients.NewInventoryConnection(logger)
+	if err != nil {
+		panic(err)
+	}
+
+	inventoryClient := grpcClients.NewCircuitBreakerInventoryClient(logger, grpcClients.NewInventoryClient(logger, telemetryApp, inventoryConnection))
+	productCache, err := cache.NewProductCache(logger, redisClient)
+	if err != nil {
+		panic(err)
+	}
 
-	inventoryClient := grpcClients.NewInventoryClient(logger, telemetryApp)
-	getProductByIdUseCase := appUseCases.NewGetProductByIdUseCase(inventoryClient)
-	createProductUseCase := appUseCases.NewCreateProductUseCase(inventoryClient)
-	inventoryHandler := handlers.NewInventoryHandler(logger, validatoR, getProductByIdUseCase, createProductUseCase)
-	inventoryRoutes := presenters.NewInventoryRoutes(logger, authenticationMiddleware, inventoryHandler)
+	getProductByIdUseCase := appUseCases.NewGetProductByIdUseCase(inventoryClient, productCache)
+	getProductsUseCase := appUseCases.NewGetProductsUseCase(inventoryClient, productCache)
+	getProductsByTypeUseCase := appUseCases.NewGetProductsByTypeUseCase(inventoryClient, productCache)
+	createProductUseCase := appUseCases.NewCreateProductUseCase(inventoryClient, productCache)
+	updateProductUseCase := appUseCases.NewUpdateProductUseCase(inventoryClient, productCache)
+	inventoryHandler := handlers.NewInventoryHandler(logger, validatoR, getProductByIdUseCase, getProductsUseCase, getProductsByTypeUseCase, createProductUseCase, updateProductUseCase)
+	inventoryRoutes := presenters.NewInventoryRoutes(logger, authenticationMiddleware, authorizationMiddleware, inventoryHandler)
 
 	pruchaseUseCase := appUseCases.NewPruchaseUseCase(messageBroker)
 	purchaseHandler := handlers.NewPurchaseHandler(logger, validatoR, pruchaseUseCase)
@@ -84,12 +154,67 @@ func NewContainer() webApiContainer {
 		logger,
 		httpServer,
 		messageBroker,
+		migrator,
+		dbConnection,
+		statements,
+
+		accessTokenManager,
+		inventoryConnection,
+		redisClient,
 
 		usersRoutes,
+		profileRoutes,
 		authenticationRoutes,
+		passwordResetRoutes,
+		mfaRoutes,
+		jwksRoutes,
 		inventoryRoutes,
 		pruchaseRoutes,
 
 		telemetryApp,
 	}
 }
+
+func dbConnectionFromEnv() (*sql.DB, error) {
+	if os.Getenv("DB_DRIVER") == database.SqliteDriver {
+		return database.GetConnection(database.SqliteDriver, database.SqliteConnectionString(os.Getenv("DB_NAME")))
+	}
+
+	connectionString := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_USER"),
+		os.Getenv("DB_PASSWORD"),
+		os.Getenv("DB_NAME"),
+	)
+
+	return database.GetConnection(
+		os.Getenv("DB_DRIVER"),
+		connectionString,
+	)
+}
+
+// newUserRepository follows DB_DRIVER. The other repositories run unchanged on
+// the embedded database.
+func newUserRepository(logger interfaces.ILogger, statements database.IStatementCache, telemetryApp telemetry.ITelemetry) interfaces.IUserRepository {
+	if os.Getenv("DB_DRIVER") == database.SqliteDriver {
+		return repositories.NewSqliteUserRepository(logger, statements, telemetryApp)
+	}
+
+	return repositories.NewUserRepository(logger, statements, telemetryApp)
+}
+
+// newLoginAttemptStore picks where failed sign ins are counted. Instances behind
+// a load balancer must share the Postgres store, otherwise every replica grants
+// its own attempt budget.
+func newLoginAttemptStore(logger interfaces.ILogger, dbConnection *sql.DB, telemetryApp telemetry.ITelemetry) interfaces.ILoginAttemptStore {
+	switch os.Getenv("LOGIN_ATTEMPT_STORE") {
+	case "postgres":
+		return repositories.NewLoginAttemptRepository(logger, dbConnection, telemetryApp)
+	case "", "memory":
+		return loginAttemptStore.NewMemoryLoginAttemptStore()
+	default:
+		panic(fmt.Sprintf("unknown LOGIN_ATTEMPT_STORE %q", os.Getenv("LOGIN_ATTEMPT_STORE")))
+	}
+}
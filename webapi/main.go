@@ -2,10 +2,20 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"webapi/cmd"
 )
 
 func main() {
-	log.Fatal(cmd.WebApi())
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := cmd.Migrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := cmd.WebApi(); err != nil {
+		log.Fatal(err)
+	}
 }
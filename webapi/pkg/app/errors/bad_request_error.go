@@ -2,12 +2,17 @@ package errors
 
 type BadRequestError struct {
 	Message string
+	Details []string
 }
 
 func (e BadRequestError) Error() string {
 	return e.Message
 }
 
+func (e BadRequestError) ErrorDetails() []string {
+	return e.Details
+}
+
 func NewBadRequestError(m string) error {
 	return BadRequestError{Message: m}
 }
@@ -2,12 +2,17 @@ package errors
 
 type ConflictError struct {
 	Message string
+	Details []string
 }
 
 func (e ConflictError) Error() string {
 	return e.Message
 }
 
+func (e ConflictError) ErrorDetails() []string {
+	return e.Details
+}
+
 func NewConflictError(m string) error {
 	return ConflictError{Message: m}
 }
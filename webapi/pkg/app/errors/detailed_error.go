@@ -0,0 +1,9 @@
+package errors
+
+// IDetailedError is implemented by every error in this package. Details hold
+// what a remote service reported besides its message, such as the fields that
+// failed validation.
+type IDetailedError interface {
+	error
+	ErrorDetails() []string
+}
@@ -0,0 +1,18 @@
+package errors
+
+type ForbiddenError struct {
+	Message string
+	Details []string
+}
+
+func (e ForbiddenError) Error() string {
+	return e.Message
+}
+
+func (e ForbiddenError) ErrorDetails() []string {
+	return e.Details
+}
+
+func NewForbiddenError(m string) error {
+	return ForbiddenError{Message: m}
+}
@@ -0,0 +1,13 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_Forbidden_error(t *testing.T) {
+	err := NewForbiddenError("forbidden")
+
+	assert.EqualError(t, err, "forbidden", "the error message must be the same message when the error was created")
+}
@@ -0,0 +1,19 @@
+package errors
+
+// GatewayTimeoutError reports a dependency that did not answer in time.
+type GatewayTimeoutError struct {
+	Message string
+	Details []string
+}
+
+func (e GatewayTimeoutError) Error() string {
+	return e.Message
+}
+
+func (e GatewayTimeoutError) ErrorDetails() []string {
+	return e.Details
+}
+
+func NewGatewayTimeoutError(m string) error {
+	return GatewayTimeoutError{Message: m}
+}
@@ -0,0 +1,13 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_GatewayTimeout_error(t *testing.T) {
+	err := NewGatewayTimeoutError("gateway timeout")
+
+	assert.EqualError(t, err, "gateway timeout", "the error message must be the same message when the error was created")
+}
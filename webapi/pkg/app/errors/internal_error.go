@@ -2,12 +2,17 @@ package errors
 
 type InternalError struct {
 	Message string
+	Details []string
 }
 
 func (e InternalError) Error() string {
 	return e.Message
 }
 
+func (e InternalError) ErrorDetails() []string {
+	return e.Details
+}
+
 func NewInternalError(m string) error {
 	return InternalError{Message: m}
 }
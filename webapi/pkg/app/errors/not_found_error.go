@@ -2,12 +2,17 @@ package errors
 
 type NotFoundError struct {
 	Message string
+	Details []string
 }
 
 func (e NotFoundError) Error() string {
 	return e.Message
 }
 
+func (e NotFoundError) ErrorDetails() []string {
+	return e.Details
+}
+
 func NewNotFoundError(m string) error {
 	return NotFoundError{Message: m}
 }
@@ -0,0 +1,23 @@
+package errors
+
+import "time"
+
+// ServiceUnavailableError reports a dependency that is down or shedding load.
+// RetryAfter is zero when there is no estimate of when it will be back.
+type ServiceUnavailableError struct {
+	Message    string
+	RetryAfter time.Duration
+	Details    []string
+}
+
+func (e ServiceUnavailableError) Error() string {
+	return e.Message
+}
+
+func (e ServiceUnavailableError) ErrorDetails() []string {
+	return e.Details
+}
+
+func NewServiceUnavailableError(m string, retryAfter time.Duration) error {
+	return ServiceUnavailableError{Message: m, RetryAfter: retryAfter}
+}
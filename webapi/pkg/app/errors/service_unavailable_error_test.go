@@ -0,0 +1,15 @@
+package errors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_ServiceUnavailable_error(t *testing.T) {
+	err := NewServiceUnavailableError("service unavailable", time.Second*30)
+
+	assert.EqualError(t, err, "service unavailable", "the error message must be the same message when the error was created")
+	assert.Equal(t, time.Second*30, err.(ServiceUnavailableError).RetryAfter)
+}
@@ -0,0 +1,21 @@
+package errors
+
+import "time"
+
+type TooManyRequestsError struct {
+	Message    string
+	RetryAfter time.Duration
+	Details    []string
+}
+
+func (e TooManyRequestsError) Error() string {
+	return e.Message
+}
+
+func (e TooManyRequestsError) ErrorDetails() []string {
+	return e.Details
+}
+
+func NewTooManyRequestsError(m string, retryAfter time.Duration) error {
+	return TooManyRequestsError{Message: m, RetryAfter: retryAfter}
+}
@@ -0,0 +1,15 @@
+package errors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_TooManyRequests_error(t *testing.T) {
+	err := NewTooManyRequestsError("too many requests", time.Minute)
+
+	assert.EqualError(t, err, "too many requests", "the error message must be the same message when the error was created")
+	assert.Equal(t, time.Minute, err.(TooManyRequestsError).RetryAfter)
+}
@@ -2,12 +2,17 @@ package errors
 
 type UnauthorizeError struct {
 	Message string
+	Details []string
 }
 
 func (e UnauthorizeError) Error() string {
 	return e.Message
 }
 
+func (e UnauthorizeError) ErrorDetails() []string {
+	return e.Details
+}
+
 func NewUnauthorizeError(m string) error {
 	return UnauthorizeError{Message: m}
 }
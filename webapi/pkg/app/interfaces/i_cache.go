@@ -0,0 +1,12 @@
+package interfaces
+
+import "context"
+
+// ICache keeps serialized values for a while. A cache that cannot be reached
+// reports a miss instead of failing the request, callers fall back to the
+// source of truth.
+type ICache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte)
+	Delete(ctx context.Context, key string)
+}
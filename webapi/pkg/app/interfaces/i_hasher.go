@@ -3,4 +3,5 @@ package interfaces
 type IHasher interface {
 	Hahser(text string) (string, error)
 	Verify(originalText, hashedText string) bool
+	NeedsRehash(hashedText string) bool
 }
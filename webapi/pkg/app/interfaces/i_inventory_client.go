@@ -7,5 +7,8 @@ import (
 
 type IIventoryClient interface {
 	GetProductById(ctx context.Context, id string) (dtos.ProductDto, error)
+	GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error)
+	GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error)
+	UpdateProduct(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error)
 	RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error)
 }
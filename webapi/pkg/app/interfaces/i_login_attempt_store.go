@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+	"webapi/pkg/domain/entities"
+)
+
+type ILoginAttemptStore interface {
+	Find(ctx context.Context, key string) (*entities.LoginAttempt, error)
+	RegisterFailure(ctx context.Context, key string, window time.Duration) (entities.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
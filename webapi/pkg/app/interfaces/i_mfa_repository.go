@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"webapi/pkg/domain/entities"
+)
+
+type IMfaRepository interface {
+	FindByUserId(ctx context.Context, userId int) (*entities.UserMfa, error)
+	SavePending(ctx context.Context, userId int, secret string) error
+	Enable(ctx context.Context, userId int, recoveryCodeHashes []string) error
+	MarkStepUsed(ctx context.Context, userId int, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, userId int, codeHash string) (bool, error)
+	Delete(ctx context.Context, userId int) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+)
+
+type IPasswordResetRepository interface {
+	Create(ctx context.Context, dto dtos.CreatePasswordResetDto) error
+	Consume(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error)
+	InvalidateByUserId(ctx context.Context, userId int) error
+}
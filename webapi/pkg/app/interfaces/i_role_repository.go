@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"webapi/pkg/domain/entities"
+)
+
+type IRoleRepository interface {
+	FindByUserId(ctx context.Context, userId int) ([]entities.Role, error)
+	AssignToUser(ctx context.Context, userId int, roleName string) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+)
+
+type ISessionRepository interface {
+	Create(ctx context.Context, dto dtos.CreateSessionDto) (*entities.Session, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error)
+	FindLiveByUserId(ctx context.Context, userId int) ([]entities.Session, error)
+	MarkAsRotated(ctx context.Context, id int) (bool, error)
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeByAccessTokenId(ctx context.Context, accessTokenId string) error
+	RevokeAllByUserId(ctx context.Context, userId int) error
+}
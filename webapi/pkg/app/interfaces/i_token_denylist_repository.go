@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+type ITokenDenylistRepository interface {
+	Add(ctx context.Context, tokenId string, expiresAt time.Time) error
+	// Contains takes when the token expires on its own, an answer is never
+	// kept past it.
+	Contains(ctx context.Context, tokenId string, expiresAt time.Time) (bool, error)
+}
@@ -7,4 +7,8 @@ import (
 type ITokenManager interface {
 	GenerateToken(tokenData dtos.TokenDataDto) (string, error)
 	VerifyToken(token string) (*dtos.SessionDto, error)
+	GenerateOpaqueToken() (string, error)
+	HashOpaqueToken(token string) string
+	JsonWebKeys() []dtos.JsonWebKeyDto
+	Close() error
 }
@@ -0,0 +1,8 @@
+package interfaces
+
+type ITotp interface {
+	GenerateSecret() (string, error)
+	ProvisioningUri(secret, issuer, accountName string) string
+	Verify(secret, code string) (int64, bool)
+	GenerateRecoveryCodes(count int) ([]string, error)
+}
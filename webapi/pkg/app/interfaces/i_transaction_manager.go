@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// ITransactionManager runs fn atomically. The transaction travels in the
+// context handed to fn, and repositories called with that context take part in
+// it. Calling WithinTransaction again inside fn opens a savepoint, so an inner
+// failure only undoes the inner work.
+type ITransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -10,4 +10,8 @@ type IUserRepository interface {
 	FindById(ctx context.Context, id int) (*entities.User, error)
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	Create(ctx context.Context, dto dtos.CreateUserDto) (*entities.User, error)
+	UpdatePassword(ctx context.Context, id int, password string) error
+	MarkEmailAsVerified(ctx context.Context, id int) error
+	Update(ctx context.Context, id int, dto dtos.UpdateUserDto) (*entities.User, error)
+	SoftDelete(ctx context.Context, id int) error
 }
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type changePasswordUseCase struct {
+	repository interfaces.IUserRepository
+	hasher     interfaces.IHasher
+	throttler  loginThrottler
+	revoker    sessionRevoker
+}
+
+// Perform replaces the password after checking the current one. Wrong guesses
+// count against the same budget as sign in, so a stolen access token cannot be
+// used to brute force the password, and every session is revoked afterwards.
+func (pst changePasswordUseCase) Perform(ctx context.Context, dto dtos.ChangePasswordDto) error {
+	user, err := pst.repository.FindById(ctx, dto.UserId)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return errors.NewNotFoundError("User not found")
+	}
+
+	key := accountAttemptKey(user.Email)
+	if err := pst.throttler.Check(ctx, key); err != nil {
+		return err
+	}
+
+	if !pst.hasher.Verify(dto.CurrentPassword, user.Password) {
+		if err := pst.throttler.RegisterFailure(ctx, key); err != nil {
+			return err
+		}
+
+		return errors.NewUnauthorizeError("Invalid credentials")
+	}
+
+	if err := pst.throttler.RegisterSuccess(ctx, key); err != nil {
+		return err
+	}
+
+	hashedPassword, err := pst.hasher.Hahser(dto.NewPassword)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.repository.UpdatePassword(ctx, user.Id, hashedPassword); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.revoker.RevokeAll(ctx, user.Id); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewChangePasswordUseCase(
+	repository interfaces.IUserRepository,
+	sessionRepository interfaces.ISessionRepository,
+	denylist interfaces.ITokenDenylistRepository,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	hasher interfaces.IHasher,
+	logger interfaces.ILogger,
+) usecases.IChangePasswordUseCase {
+	return changePasswordUseCase{
+		repository,
+		hasher,
+		newLoginThrottler(loginAttemptStore, logger),
+		newSessionRevoker(sessionRepository, denylist),
+	}
+}
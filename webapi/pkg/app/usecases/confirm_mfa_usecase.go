@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type confirmMfaUseCase struct {
+	mfaRepository interfaces.IMfaRepository
+	totp          interfaces.ITotp
+	verifier      mfaVerifier
+}
+
+// Perform enables two-factor authentication once the user proves the pending
+// secret works, and returns the recovery codes. They are only ever shown here;
+// the database keeps their hashes.
+func (pst confirmMfaUseCase) Perform(ctx context.Context, dto dtos.MfaCodeDto) ([]string, error) {
+	mfa, err := pst.mfaRepository.FindByUserId(ctx, dto.UserId)
+	if err != nil {
+		return nil, errors.NewInternalError(err.Error())
+	}
+
+	if mfa == nil {
+		return nil, errors.NewBadRequestError("Two-factor authentication enrollment not started")
+	}
+
+	if mfa.IsEnabled() {
+		return nil, errors.NewConflictError("Two-factor authentication already enabled")
+	}
+
+	ok, err := pst.verifier.Verify(ctx, *mfa, dto.Code, dto.ClientIp, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, errors.NewBadRequestError("Invalid two-factor code")
+	}
+
+	codes, err := pst.totp.GenerateRecoveryCodes(mfaRecoveryCodesCount)
+	if err != nil {
+		return nil, errors.NewInternalError(err.Error())
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, pst.verifier.hashRecoveryCode(code))
+	}
+
+	if err := pst.mfaRepository.Enable(ctx, dto.UserId, hashes); err != nil {
+		return nil, errors.NewInternalError(err.Error())
+	}
+
+	return codes, nil
+}
+
+func NewConfirmMfaUseCase(
+	mfaRepository interfaces.IMfaRepository,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	totp interfaces.ITotp,
+	tokenManager interfaces.ITokenManager,
+	logger interfaces.ILogger,
+) usecases.IConfirmMfaUseCase {
+	return confirmMfaUseCase{
+		mfaRepository,
+		totp,
+		newMfaVerifier(mfaRepository, totp, tokenManager, loginAttemptStore, logger),
+	}
+}
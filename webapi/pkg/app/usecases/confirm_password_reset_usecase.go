@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type confirmPasswordResetUseCase struct {
+	userRepository          interfaces.IUserRepository
+	passwordResetRepository interfaces.IPasswordResetRepository
+	hasher                  interfaces.IHasher
+	tokenManager            interfaces.ITokenManager
+	revoker                 sessionRevoker
+}
+
+// Perform redeems a reset token, stores the new password and signs the user
+// out of every device, since whoever held the old password may still be in.
+func (pst confirmPasswordResetUseCase) Perform(ctx context.Context, dto dtos.ConfirmPasswordResetDto) error {
+	reset, err := pst.passwordResetRepository.Consume(ctx, pst.tokenManager.HashOpaqueToken(dto.Token))
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if reset == nil {
+		return errors.NewBadRequestError("Invalid or expired reset token")
+	}
+
+	hashedPassword, err := pst.hasher.Hahser(dto.Password)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.userRepository.UpdatePassword(ctx, reset.UserId, hashedPassword); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.passwordResetRepository.InvalidateByUserId(ctx, reset.UserId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.revoker.RevokeAll(ctx, reset.UserId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewConfirmPasswordResetUseCase(
+	userRepository interfaces.IUserRepository,
+	passwordResetRepository interfaces.IPasswordResetRepository,
+	sessionRepository interfaces.ISessionRepository,
+	denylist interfaces.ITokenDenylistRepository,
+	hasher interfaces.IHasher,
+	tokenManager interfaces.ITokenManager,
+) usecases.IConfirmPasswordResetUseCase {
+	return confirmPasswordResetUseCase{
+		userRepository,
+		passwordResetRepository,
+		hasher,
+		tokenManager,
+		newSessionRevoker(sessionRepository, denylist),
+	}
+}
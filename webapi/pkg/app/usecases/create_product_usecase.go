@@ -9,14 +9,24 @@ import (
 
 type createProductUseCase struct {
 	inventoryClient interfaces.IIventoryClient
+	cache           productCache
 }
 
+// Perform drops the cached lists once the product is registered, so it shows
+// up in the catalog right away.
 func (pst createProductUseCase) Perform(ctx context.Context, dto dtos.ProductDto) (dtos.ProductDto, error) {
-	return pst.inventoryClient.RegisterProduct(ctx, dto)
+	product, err := pst.inventoryClient.RegisterProduct(ctx, dto)
+	if err != nil {
+		return product, err
+	}
+
+	pst.cache.invalidate(ctx, "")
+	return product, nil
 }
 
-func NewCreateProductUseCase(inventoryClient interfaces.IIventoryClient) usecases.ICreateProductUseCase {
+func NewCreateProductUseCase(inventoryClient interfaces.IIventoryClient, cache interfaces.ICache) usecases.ICreateProductUseCase {
 	return createProductUseCase{
 		inventoryClient,
+		newProductCache(cache),
 	}
 }
@@ -24,3 +24,11 @@ func Test_CreateProductUC_Should_Execute_Correctly(t *testing.T) {
 	assert.NoError(t, err)
 	assert.IsType(t, result, dtos.ProductDto{})
 }
+
+func Test_CreateProductUC_Should_Invalidate_Lists(t *testing.T) {
+	sut := newCreateProductUsecaseToTest(map[string]mockConfigure{})
+
+	sut.useCase.Perform(context.Background(), dtos.ProductDto{})
+
+	assert.Equal(t, []string{productListVersionKey}, sut.cache.deleted)
+}
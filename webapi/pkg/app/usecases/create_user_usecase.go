@@ -2,21 +2,31 @@ package usecases
 
 import (
 	"context"
-	"os"
-	"time"
 	"webapi/pkg/app/errors"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
 	"webapi/pkg/domain/usecases"
+
+	"go.uber.org/zap"
 )
 
 type createUserUseCase struct {
-	repository   interfaces.IUserRepository
-	hasher       interfaces.IHasher
-	tokenManager interfaces.ITokenManager
+	repository     interfaces.IUserRepository
+	roleRepository interfaces.IRoleRepository
+	transaction    interfaces.ITransactionManager
+	hasher         interfaces.IHasher
+	issuer         sessionIssuer
+	verification   emailVerificationSender
+	logger         interfaces.ILogger
 }
 
+// Perform registers the account. The FindByEmail check gives the common case a
+// clean answer; concurrent signups racing past it are stopped by the unique
+// email index, which the repository reports as a ConflictError.
 func (pst createUserUseCase) Perform(ctx context.Context, dto dtos.CreateUserDto) (dtos.CreatedUserDto, error) {
+	dto.Email = entities.NormalizeEmail(dto.Email)
+
 	user, err := pst.repository.FindByEmail(ctx, dto.Email)
 	if err != nil {
 		return dtos.CreatedUserDto{}, errors.NewInternalError(err.Error())
@@ -32,36 +42,70 @@ func (pst createUserUseCase) Perform(ctx context.Context, dto dtos.CreateUserDto
 	}
 
 	dto.Password = hashedPassword
-	user, err = pst.repository.Create(ctx, dto)
-	if err != nil {
-		return dtos.CreatedUserDto{}, errors.NewInternalError(err.Error())
-	}
 
-	expireIn := time.Now().Add(time.Hour)
-	accessToken, err := pst.tokenManager.GenerateToken(dtos.TokenDataDto{
-		Id:       user.Id,
-		Audience: "WebApi",
-		ExpireIn: expireIn,
+	// The user, its role and its first session are written together, so a
+	// failure half way does not leave an account nobody can sign in to.
+	var session dtos.SessionDto
+	err = pst.transaction.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err = pst.repository.Create(ctx, dto)
+		if err != nil {
+			return err
+		}
+
+		if err := pst.roleRepository.AssignToUser(ctx, user.Id, entities.RoleCustomer); err != nil {
+			return err
+		}
+
+		session, err = pst.issuer.Issue(ctx, user.Id, "")
+		return err
 	})
+	if _, conflict := err.(errors.ConflictError); conflict {
+		return dtos.CreatedUserDto{}, err
+	}
 	if err != nil {
 		return dtos.CreatedUserDto{}, errors.NewInternalError(err.Error())
 	}
 
+	// The account already exists at this point, failing the signup would only
+	// lock the user out. They can ask for a new link instead.
+	if err := pst.verification.Send(ctx, *user); err != nil {
+		pst.logger.Warn(
+			"[CreateUserUseCase::Perform] could not send email verification",
+			zap.Int("userId", user.Id),
+			zap.Error(err),
+		)
+	}
+
 	return dtos.CreatedUserDto{
-		Id:          user.Id,
-		Name:        user.Name,
-		Email:       user.Email,
-		CreatedAt:   user.CreatedAt,
-		AccessToken: accessToken,
-		Kind:        os.Getenv("TOKEN_KIND"),
-		ExpireIn:    expireIn,
+		Id:              user.Id,
+		Name:            user.Name,
+		Email:           user.Email,
+		CreatedAt:       user.CreatedAt,
+		AccessToken:     session.AccessToken,
+		Kind:            session.Kind,
+		ExpireIn:        session.ExpireIn,
+		RefreshToken:    session.RefreshToken,
+		RefreshExpireIn: session.RefreshExpireIn,
 	}, nil
 }
 
-func NewCreateUserUseCase(repository interfaces.IUserRepository, hasher interfaces.IHasher, tokenManager interfaces.ITokenManager) usecases.ICreateUserUseCase {
+func NewCreateUserUseCase(
+	repository interfaces.IUserRepository,
+	sessionRepository interfaces.ISessionRepository,
+	roleRepository interfaces.IRoleRepository,
+	transaction interfaces.ITransactionManager,
+	hasher interfaces.IHasher,
+	tokenManager interfaces.ITokenManager,
+	messageBroker interfaces.IMessageBroker,
+	logger interfaces.ILogger,
+) usecases.ICreateUserUseCase {
 	return createUserUseCase{
 		repository,
+		roleRepository,
+		transaction,
 		hasher,
-		tokenManager,
+		newSessionIssuer(tokenManager, sessionRepository, roleRepository),
+		newEmailVerificationSender(tokenManager, messageBroker),
+		logger,
 	}
 }
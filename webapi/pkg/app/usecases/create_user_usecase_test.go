@@ -88,6 +88,20 @@ func Test_CreateUserUC_Should_Return_InternalError_If_Some_Error_Occur_When_Crea
 	assert.IsType(t, err, inernalError.InternalError{})
 }
 
+func Test_CreateUserUC_Should_Return_ConflictError_If_Concurrent_Signup_Won_The_Race(t *testing.T) {
+	configs := map[string]mockConfigure{
+		"userRepository": {
+			method:      "Create",
+			customError: inernalError.NewConflictError("Email already registered"),
+		},
+	}
+	sut := newCreateUserUseCaseToTest(configs)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.CreateUserDto{})
+
+	assert.IsType(t, err, inernalError.ConflictError{})
+}
+
 func Test_CreateUserUC_Should_Return_InternalError_If_Some_Error_Occur_When_Create_Token(t *testing.T) {
 	configs := map[string]mockConfigure{
 		"tokenManager": {
@@ -103,3 +117,47 @@ func Test_CreateUserUC_Should_Return_InternalError_If_Some_Error_Occur_When_Crea
 	assert.Error(t, err)
 	assert.IsType(t, err, inernalError.InternalError{})
 }
+
+func Test_CreateUserUC_Should_Return_InternalError_If_Default_Role_Could_Not_Be_Assigned(t *testing.T) {
+	configs := map[string]mockConfigure{
+		"roleRepository": {
+			method:      "AssignToUser",
+			customError: errors.New("some error"),
+		},
+	}
+	sut := newCreateUserUseCaseToTest(configs)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.CreateUserDto{})
+
+	assert.Error(t, err)
+	assert.IsType(t, err, inernalError.InternalError{})
+}
+
+func Test_CreateUserUC_Should_Create_User_Even_If_Verification_Could_Not_Be_Sent(t *testing.T) {
+	configs := map[string]mockConfigure{
+		"messageBroker": {
+			method:      "Publisher",
+			customError: errors.New("some error"),
+		},
+	}
+	sut := newCreateUserUseCaseToTest(configs)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.CreateUserDto{})
+
+	assert.NoError(t, err)
+}
+
+func Test_CreateUserUC_Should_Return_InternalError_If_Transaction_Could_Not_Be_Committed(t *testing.T) {
+	configs := map[string]mockConfigure{
+		"transaction": {
+			method:      "WithinTransaction",
+			customError: errors.New("some error"),
+		},
+	}
+	sut := newCreateUserUseCaseToTest(configs)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.CreateUserDto{})
+
+	assert.Error(t, err)
+	assert.IsType(t, err, inernalError.InternalError{})
+}
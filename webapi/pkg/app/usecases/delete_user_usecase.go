@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/usecases"
+)
+
+type deleteUserUseCase struct {
+	repository interfaces.IUserRepository
+	revoker    sessionRevoker
+}
+
+// Perform soft deletes the account and signs it out everywhere. The row is kept
+// so orders and audit trails still resolve the user.
+func (pst deleteUserUseCase) Perform(ctx context.Context, userId int) error {
+	if err := pst.repository.SoftDelete(ctx, userId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.revoker.RevokeAll(ctx, userId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewDeleteUserUseCase(
+	repository interfaces.IUserRepository,
+	sessionRepository interfaces.ISessionRepository,
+	denylist interfaces.ITokenDenylistRepository,
+) usecases.IDeleteUserUseCase {
+	return deleteUserUseCase{
+		repository,
+		newSessionRevoker(sessionRepository, denylist),
+	}
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type disableMfaUseCase struct {
+	mfaRepository interfaces.IMfaRepository
+	verifier      mfaVerifier
+}
+
+// Perform turns two-factor authentication off. It asks for a code even though
+// the caller is signed in, so a stolen access token alone cannot remove it.
+func (pst disableMfaUseCase) Perform(ctx context.Context, dto dtos.MfaCodeDto) error {
+	mfa, err := pst.mfaRepository.FindByUserId(ctx, dto.UserId)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if mfa == nil || !mfa.IsEnabled() {
+		return errors.NewBadRequestError("Two-factor authentication is not enabled")
+	}
+
+	ok, err := pst.verifier.Verify(ctx, *mfa, dto.Code, dto.ClientIp, true)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.NewBadRequestError("Invalid two-factor code")
+	}
+
+	if err := pst.mfaRepository.Delete(ctx, dto.UserId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewDisableMfaUseCase(
+	mfaRepository interfaces.IMfaRepository,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	totp interfaces.ITotp,
+	tokenManager interfaces.ITokenManager,
+	logger interfaces.ILogger,
+) usecases.IDisableMfaUseCase {
+	return disableMfaUseCase{
+		mfaRepository,
+		newMfaVerifier(mfaRepository, totp, tokenManager, loginAttemptStore, logger),
+	}
+}
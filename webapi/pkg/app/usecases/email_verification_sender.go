@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+)
+
+const EmailVerificationRequestedEvent = "email_verification_requested"
+
+const defaultEmailVerificationExpireIn = time.Hour * 24
+
+type emailVerificationSender struct {
+	tokenManager interfaces.ITokenManager
+	publisher    mailerPublisher
+}
+
+// Send signs an expiring verification token for the user's current email and
+// asks the mailer to deliver the link built from EMAIL_VERIFICATION_URL.
+func (pst emailVerificationSender) Send(ctx context.Context, user entities.User) error {
+	tokenId, err := pst.tokenManager.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(emailVerificationExpireIn())
+	token, err := pst.tokenManager.GenerateToken(dtos.TokenDataDto{
+		Id:       user.Id,
+		TokenId:  tokenId,
+		Use:      dtos.TokenUseEmailVerification,
+		Email:    entities.NormalizeEmail(user.Email),
+		Audience: os.Getenv("APP_ISSUER"),
+		ExpireIn: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return pst.publisher.Publish(ctx, dtos.EmailVerificationRequestedDto{
+		Event:     EmailVerificationRequestedEvent,
+		UserId:    user.Id,
+		Name:      user.Name,
+		Email:     user.Email,
+		Link:      fmt.Sprintf("%s?token=%s", os.Getenv("EMAIL_VERIFICATION_URL"), url.QueryEscape(token)),
+		ExpiresAt: expiresAt,
+	})
+}
+
+func emailVerificationExpireIn() time.Duration {
+	expireIn, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_EXPIRE_IN"))
+	if err != nil || expireIn <= 0 {
+		return defaultEmailVerificationExpireIn
+	}
+
+	return expireIn
+}
+
+func newEmailVerificationSender(tokenManager interfaces.ITokenManager, messageBroker interfaces.IMessageBroker) emailVerificationSender {
+	return emailVerificationSender{
+		tokenManager,
+		newMailerPublisher(messageBroker),
+	}
+}
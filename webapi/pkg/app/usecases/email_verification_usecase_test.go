@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyEmailUC_Should_Verify_Email_Correctly(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseEmailVerification, Email: userEmailToTest},
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.NoError(t, err)
+}
+
+func Test_VerifyEmailUC_Should_Return_BadRequestError_If_Token_Is_Invalid(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: (*dtos.SessionDto)(nil),
+			customError:  errors.New("jwt is expired"),
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyEmailUC_Should_Reject_Access_Tokens(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseAccess},
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyEmailUC_Should_Return_InternalError_If_Update_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseEmailVerification, Email: userEmailToTest},
+		},
+		"userRepository": {
+			method:      "MarkEmailAsVerified",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_VerifyEmailUC_Should_Reject_Link_Issued_Before_Email_Changed(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseEmailVerification, Email: "old@email.com"},
+		},
+		"userRepository": {
+			method:       "FindById",
+			customResult: &entities.User{Id: 1, Email: "new@email.com"},
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyEmailUC_Should_Reject_Token_Without_Email(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseEmailVerification},
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyEmailUC_Should_Reject_Used_Link(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, TokenId: "jti", Use: dtos.TokenUseEmailVerification, Email: userEmailToTest},
+		},
+		"denylist": {
+			method:       "Contains",
+			customResult: true,
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyEmailUC_Should_Return_InternalError_If_Link_Cannot_Be_Used_Up(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, TokenId: "jti", Use: dtos.TokenUseEmailVerification, Email: userEmailToTest},
+		},
+		"denylist": {
+			method:      "Add",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.verify.Perform(context.Background(), "token")
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_RequestEmailVerificationUC_Should_Publish_Event_Correctly(t *testing.T) {
+	sut := newEmailVerificationUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.request.Perform(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
+
+func Test_RequestEmailVerificationUC_Should_Return_ConflictError_If_Already_Verified(t *testing.T) {
+	verifiedAt := time.Now()
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: &entities.User{Id: 1, EmailVerifiedAt: &verifiedAt},
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.ConflictError{})
+}
+
+func Test_RequestEmailVerificationUC_Should_Return_InternalError_If_Publish_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"messageBroker": {
+			method:      "Publisher",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newEmailVerificationUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
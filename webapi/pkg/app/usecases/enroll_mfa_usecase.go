@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type enrollMfaUseCase struct {
+	userRepository interfaces.IUserRepository
+	mfaRepository  interfaces.IMfaRepository
+	totp           interfaces.ITotp
+}
+
+// Perform starts an enrollment with a fresh secret. It stays inactive until
+// ConfirmMfa proves the authenticator app produces valid codes.
+func (pst enrollMfaUseCase) Perform(ctx context.Context, userId int) (dtos.MfaEnrollmentDto, error) {
+	user, err := pst.userRepository.FindById(ctx, userId)
+	if err != nil {
+		return dtos.MfaEnrollmentDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return dtos.MfaEnrollmentDto{}, errors.NewNotFoundError("User not found")
+	}
+
+	mfa, err := pst.mfaRepository.FindByUserId(ctx, userId)
+	if err != nil {
+		return dtos.MfaEnrollmentDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if mfa != nil && mfa.IsEnabled() {
+		return dtos.MfaEnrollmentDto{}, errors.NewConflictError("Two-factor authentication already enabled")
+	}
+
+	secret, err := pst.totp.GenerateSecret()
+	if err != nil {
+		return dtos.MfaEnrollmentDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.mfaRepository.SavePending(ctx, userId, secret); err != nil {
+		return dtos.MfaEnrollmentDto{}, errors.NewInternalError(err.Error())
+	}
+
+	return dtos.MfaEnrollmentDto{
+		Secret:          secret,
+		ProvisioningUri: pst.totp.ProvisioningUri(secret, os.Getenv("APP_ISSUER"), user.Email),
+	}, nil
+}
+
+func NewEnrollMfaUseCase(
+	userRepository interfaces.IUserRepository,
+	mfaRepository interfaces.IMfaRepository,
+	totp interfaces.ITotp,
+) usecases.IEnrollMfaUseCase {
+	return enrollMfaUseCase{
+		userRepository,
+		mfaRepository,
+		totp,
+	}
+}
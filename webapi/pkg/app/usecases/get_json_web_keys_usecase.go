@@ -0,0 +1,20 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type getJsonWebKeysUseCase struct {
+	tokenManager interfaces.ITokenManager
+}
+
+func (pst getJsonWebKeysUseCase) Perform(ctx context.Context) []dtos.JsonWebKeyDto {
+	return pst.tokenManager.JsonWebKeys()
+}
+
+func NewGetJsonWebKeysUseCase(tokenManager interfaces.ITokenManager) usecases.IGetJsonWebKeysUseCase {
+	return getJsonWebKeysUseCase{tokenManager}
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetJsonWebKeysUC_Should_Return_The_Token_Manager_Keys(t *testing.T) {
+	keys := []dtos.JsonWebKeyDto{
+		{Kid: "2021-10", Kty: "RSA", Alg: "RS256", Use: "sig", N: "n", E: "AQAB"},
+		{Kid: "2021-11", Kty: "RSA", Alg: "RS256", Use: "sig", N: "n", E: "AQAB"},
+	}
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "JsonWebKeys",
+			customResult: keys,
+		},
+	}
+
+	sut := newGetJsonWebKeysUsecaseToTest(config)
+
+	result := sut.useCase.Perform(context.Background())
+
+	assert.Equal(t, keys, result)
+}
@@ -9,12 +9,18 @@ import (
 
 type getProductByIdUseCase struct {
 	inventoryClient interfaces.IIventoryClient
+	cache           productCache
 }
 
 func (pst getProductByIdUseCase) Perform(ctx context.Context, id string) (dtos.ProductDto, error) {
-	return pst.inventoryClient.GetProductById(ctx, id)
+	product := dtos.ProductDto{}
+	err := pst.cache.readThrough(ctx, pst.cache.productKey(ctx, id), &product, func(ctx context.Context) (interface{}, error) {
+		return pst.inventoryClient.GetProductById(ctx, id)
+	})
+
+	return product, err
 }
 
-func NewGetProductByIdUseCase(inventoryClient interfaces.IIventoryClient) usecases.IGetProductByIdUseCase {
-	return getProductByIdUseCase{inventoryClient}
+func NewGetProductByIdUseCase(inventoryClient interfaces.IIventoryClient, cache interfaces.ICache) usecases.IGetProductByIdUseCase {
+	return getProductByIdUseCase{inventoryClient, newProductCache(cache)}
 }
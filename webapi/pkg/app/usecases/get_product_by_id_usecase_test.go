@@ -2,7 +2,10 @@ package usecases
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
 	"webapi/pkg/domain/dtos"
 
 	"github.com/stretchr/testify/assert"
@@ -24,3 +27,93 @@ func Test_GetProductByIdUC_Should_Execute_Correctly(t *testing.T) {
 	assert.NoError(t, err)
 	assert.IsType(t, result, dtos.ProductDto{})
 }
+
+func Test_GetProductByIdUC_Should_Read_Through_The_Cache(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProductById",
+			customResult: dtos.ProductDto{Id: "1", Title: "Title", Authors: []string{"Author"}},
+			customError:  nil,
+		},
+	}
+	sut := newGetProductByIdUsecaseToTest(config)
+
+	first, _ := sut.useCase.Perform(context.Background(), "1")
+	second, err := sut.useCase.Perform(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, "Title", second.Title)
+	assert.Equal(t, 1, sut.inventoryClient.Calls())
+}
+
+func Test_GetProductByIdUC_Should_Share_One_Call_Between_Concurrent_Misses(t *testing.T) {
+	sut := newGetProductByIdUsecaseToTest(map[string]mockConfigure{})
+	sut.inventoryClient.delay = time.Millisecond * 50
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sut.useCase.Perform(context.Background(), "1")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, sut.inventoryClient.Calls())
+}
+
+func Test_GetProductByIdUC_Should_Not_Cache_Errors(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProductById",
+			customResult: dtos.ProductDto{},
+			customError:  internalError.NewNotFoundError("product not found"),
+		},
+	}
+	sut := newGetProductByIdUsecaseToTest(config)
+
+	sut.useCase.Perform(context.Background(), "1")
+	_, err := sut.useCase.Perform(context.Background(), "1")
+
+	assert.IsType(t, internalError.NotFoundError{}, err)
+	assert.Equal(t, 2, sut.inventoryClient.Calls())
+}
+
+func Test_GetProductByIdUC_Should_Read_From_The_Source_Without_Cache(t *testing.T) {
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
+	sut := NewGetProductByIdUseCase(inventoryClient, nil)
+
+	sut.Perform(context.Background(), "1")
+	_, err := sut.Perform(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, inventoryClient.Calls())
+}
+
+func Test_GetProductByIdUC_Should_Keep_Loading_When_The_First_Caller_Gives_Up(t *testing.T) {
+	sut := newGetProductByIdUsecaseToTest(map[string]mockConfigure{})
+	sut.inventoryClient.delay = time.Millisecond * 50
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := sut.useCase.Perform(ctx, "1")
+		firstErr <- err
+	}()
+	time.Sleep(time.Millisecond * 10)
+
+	secondErr := make(chan error)
+	go func() {
+		_, err := sut.useCase.Perform(context.Background(), "1")
+		secondErr <- err
+	}()
+	time.Sleep(time.Millisecond * 10)
+	cancel()
+
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	assert.NoError(t, <-secondErr)
+	assert.Equal(t, 1, sut.inventoryClient.Calls())
+	assert.Equal(t, 0, sut.inventoryClient.Canceled())
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type getProductsByTypeUseCase struct {
+	inventoryClient interfaces.IIventoryClient
+	cache           productCache
+}
+
+func (pst getProductsByTypeUseCase) Perform(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	products := []dtos.ProductDto{}
+	key := pst.cache.listKey(ctx, "type:"+productType)
+	err := pst.cache.readThrough(ctx, key, &products, func(ctx context.Context) (interface{}, error) {
+		return pst.inventoryClient.GetProductsByType(ctx, productType)
+	})
+
+	return products, err
+}
+
+func NewGetProductsByTypeUseCase(inventoryClient interfaces.IIventoryClient, cache interfaces.ICache) usecases.IGetProductsByTypeUseCase {
+	return getProductsByTypeUseCase{inventoryClient, newProductCache(cache)}
+}
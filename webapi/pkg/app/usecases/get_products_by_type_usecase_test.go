@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetProductsByTypeUC_Should_Execute_Correctly(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProductsByType",
+			customResult: []dtos.ProductDto{{Id: "1", ProductCategory: "book"}},
+		},
+	}
+
+	sut := newGetProductsByTypeUsecaseToTest(config)
+
+	result, err := sut.useCase.Perform(context.Background(), "book")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
+func Test_GetProductsByTypeUC_Should_Return_Client_Error(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProductsByType",
+			customResult: []dtos.ProductDto(nil),
+			customError:  internalError.NewInternalError("some error"),
+		},
+	}
+
+	sut := newGetProductsByTypeUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "book")
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_GetProductsByTypeUC_Should_Cache_Each_Category(t *testing.T) {
+	sut := newGetProductsByTypeUsecaseToTest(map[string]mockConfigure{})
+
+	sut.useCase.Perform(context.Background(), "book")
+	sut.useCase.Perform(context.Background(), "book")
+	sut.useCase.Perform(context.Background(), "ebook")
+
+	assert.Equal(t, 2, sut.inventoryClient.Calls())
+}
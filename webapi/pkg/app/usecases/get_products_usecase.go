@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type getProductsUseCase struct {
+	inventoryClient interfaces.IIventoryClient
+	cache           productCache
+}
+
+func (pst getProductsUseCase) Perform(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	page := dtos.ProductsPageDto{}
+	key := pst.cache.listKey(ctx, fmt.Sprintf("page:%d:%d", limit, offset))
+	err := pst.cache.readThrough(ctx, key, &page, func(ctx context.Context) (interface{}, error) {
+		return pst.inventoryClient.GetProducts(ctx, limit, offset)
+	})
+
+	return page, err
+}
+
+func NewGetProductsUseCase(inventoryClient interfaces.IIventoryClient, cache interfaces.ICache) usecases.IGetProductsUseCase {
+	return getProductsUseCase{inventoryClient, newProductCache(cache)}
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetProductsUC_Should_Execute_Correctly(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProducts",
+			customResult: dtos.ProductsPageDto{Products: []dtos.ProductDto{{Id: "1"}}, Total: 21, Limit: 1, Offset: 20},
+		},
+	}
+
+	sut := newGetProductsUsecaseToTest(config)
+
+	result, err := sut.useCase.Perform(context.Background(), 1, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Products, 1)
+	assert.Equal(t, 21, result.Total)
+}
+
+func Test_GetProductsUC_Should_Return_Client_Error(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "GetProducts",
+			customResult: dtos.ProductsPageDto{},
+			customError:  internalError.NewInternalError("some error"),
+		},
+	}
+
+	sut := newGetProductsUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), 20, 0)
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_GetProductsUC_Should_Cache_Each_Page(t *testing.T) {
+	sut := newGetProductsUsecaseToTest(map[string]mockConfigure{})
+
+	sut.useCase.Perform(context.Background(), 20, 0)
+	sut.useCase.Perform(context.Background(), 20, 0)
+	result, err := sut.useCase.Perform(context.Background(), 20, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20, result.Offset)
+	assert.Equal(t, 2, sut.inventoryClient.Calls())
+}
+
+func Test_GetProductsUC_Should_Read_Again_Once_Lists_Are_Invalidated(t *testing.T) {
+	sut := newGetProductsUsecaseToTest(map[string]mockConfigure{})
+
+	sut.useCase.Perform(context.Background(), 20, 0)
+	newProductCache(sut.cache).invalidate(context.Background(), "")
+	sut.useCase.Perform(context.Background(), 20, 0)
+
+	assert.Equal(t, 2, sut.inventoryClient.Calls())
+}
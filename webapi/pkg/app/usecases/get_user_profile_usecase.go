@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/domain/usecases"
+)
+
+type getUserProfileUseCase struct {
+	repository interfaces.IUserRepository
+}
+
+func (pst getUserProfileUseCase) Perform(ctx context.Context, userId int) (dtos.UserProfileDto, error) {
+	user, err := pst.repository.FindById(ctx, userId)
+	if err != nil {
+		return dtos.UserProfileDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return dtos.UserProfileDto{}, errors.NewNotFoundError("User not found")
+	}
+
+	return toUserProfileDto(*user), nil
+}
+
+func toUserProfileDto(user entities.User) dtos.UserProfileDto {
+	return dtos.UserProfileDto{
+		Id:            user.Id,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+func NewGetUserProfileUseCase(repository interfaces.IUserRepository) usecases.IGetUserProfileUseCase {
+	return getUserProfileUseCase{
+		repository,
+	}
+}
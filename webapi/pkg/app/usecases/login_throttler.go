@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLoginMaxFailures      = 5
+	defaultLoginMaxFailuresPerIp = 20
+	defaultLoginFailureWindow    = time.Minute * 15
+	defaultLoginLockoutBase      = time.Minute
+	defaultLoginLockoutMax       = time.Hour
+)
+
+type loginThrottlePolicy struct {
+	maxFailures      int
+	maxFailuresPerIp int
+	window           time.Duration
+	lockoutBase      time.Duration
+	lockoutMax       time.Duration
+}
+
+// lockoutFor doubles the lockout for every failure past the allowed budget,
+// capped at lockoutMax. It returns zero while the key is still within budget.
+func (pst loginThrottlePolicy) lockoutFor(failures, maxFailures int) time.Duration {
+	if failures < maxFailures {
+		return 0
+	}
+
+	lockout := pst.lockoutBase
+	for i := maxFailures; i < failures && lockout < pst.lockoutMax; i++ {
+		lockout *= 2
+	}
+
+	if lockout > pst.lockoutMax {
+		return pst.lockoutMax
+	}
+
+	return lockout
+}
+
+// loginThrottler tracks failed sign ins per account and per client IP. The
+// counters live in an ILoginAttemptStore so every instance behind the load
+// balancer sees the same lockouts.
+type loginThrottler struct {
+	store  interfaces.ILoginAttemptStore
+	policy loginThrottlePolicy
+	logger interfaces.ILogger
+}
+
+// Check refuses the attempt while any of the keys is locked out.
+func (pst loginThrottler) Check(ctx context.Context, keys ...string) error {
+	now := time.Now()
+
+	for _, key := range keys {
+		attempt, err := pst.store.Find(ctx, key)
+		if err != nil {
+			return errors.NewInternalError(err.Error())
+		}
+
+		if attempt != nil && attempt.IsLocked(now) {
+			return errors.NewTooManyRequestsError("Too many failed attempts, try again later", attempt.LockedUntil.Sub(now))
+		}
+	}
+
+	return nil
+}
+
+func (pst loginThrottler) RegisterFailure(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		attempt, err := pst.store.RegisterFailure(ctx, key, pst.policy.window)
+		if err != nil {
+			return errors.NewInternalError(err.Error())
+		}
+
+		maxFailures := pst.policy.maxFailures
+		if strings.HasPrefix(key, "ip:") {
+			maxFailures = pst.policy.maxFailuresPerIp
+		}
+
+		lockout := pst.policy.lockoutFor(attempt.Failures, maxFailures)
+		if lockout == 0 {
+			continue
+		}
+
+		if err := pst.store.Lock(ctx, key, time.Now().Add(lockout)); err != nil {
+			return errors.NewInternalError(err.Error())
+		}
+
+		pst.traceLockout(ctx, attempt, lockout)
+	}
+
+	return nil
+}
+
+// RegisterSuccess clears the counter of the account key only; IP counters keep
+// running so one valid account cannot be used to reset a spraying client.
+func (pst loginThrottler) RegisterSuccess(ctx context.Context, key string) error {
+	if err := pst.store.Reset(ctx, key); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func (pst loginThrottler) traceLockout(ctx context.Context, attempt entities.LoginAttempt, lockout time.Duration) {
+	pst.logger.Warn(
+		"[LoginThrottler::RegisterFailure] too many failed sign in attempts, locking out",
+		zap.String("key", attempt.Key),
+		zap.Int("failures", attempt.Failures),
+		zap.Duration("lockout", lockout),
+	)
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag("auth.lockout", true)
+		span.LogKV(
+			"event", "login_lockout",
+			"key", attempt.Key,
+			"failures", attempt.Failures,
+			"lockout", lockout.String(),
+		)
+	}
+}
+
+func accountAttemptKey(email string) string {
+	return "account:" + entities.NormalizeEmail(email)
+}
+
+func mfaAttemptKey(userId int) string {
+	return "mfa:" + strconv.Itoa(userId)
+}
+
+func attemptKeys(key, clientIp string) []string {
+	keys := []string{key}
+	if clientIp != "" {
+		keys = append(keys, "ip:"+clientIp)
+	}
+
+	return keys
+}
+
+func loginThrottlePolicyFromEnv() loginThrottlePolicy {
+	return loginThrottlePolicy{
+		maxFailures:      intFromEnv("LOGIN_MAX_FAILURES", defaultLoginMaxFailures),
+		maxFailuresPerIp: intFromEnv("LOGIN_MAX_FAILURES_PER_IP", defaultLoginMaxFailuresPerIp),
+		window:           durationFromEnv("LOGIN_FAILURE_WINDOW", defaultLoginFailureWindow),
+		lockoutBase:      durationFromEnv("LOGIN_LOCKOUT_BASE", defaultLoginLockoutBase),
+		lockoutMax:       durationFromEnv("LOGIN_LOCKOUT_MAX", defaultLoginLockoutMax),
+	}
+}
+
+func intFromEnv(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+func newLoginThrottler(store interfaces.ILoginAttemptStore, logger interfaces.ILogger) loginThrottler {
+	return loginThrottler{
+		store,
+		loginThrottlePolicyFromEnv(),
+		logger,
+	}
+}
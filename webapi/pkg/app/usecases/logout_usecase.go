@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type logoutUseCase struct {
+	sessionRepository interfaces.ISessionRepository
+	denylist          interfaces.ITokenDenylistRepository
+}
+
+func (pst logoutUseCase) Perform(ctx context.Context, session dtos.SessionDto) error {
+	if session.TokenId == "" {
+		return errors.NewUnauthorizeError("Token has no identifier")
+	}
+
+	if err := pst.denylist.Add(ctx, session.TokenId, session.ExpireIn); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.sessionRepository.RevokeByAccessTokenId(ctx, session.TokenId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewLogoutUseCase(sessionRepository interfaces.ISessionRepository, denylist interfaces.ITokenDenylistRepository) usecases.ILogoutUseCase {
+	return logoutUseCase{
+		sessionRepository,
+		denylist,
+	}
+}
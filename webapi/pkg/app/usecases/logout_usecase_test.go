@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LogoutUC_Should_Revoke_Access_Token_Correctly(t *testing.T) {
+	sut := newRevokeSessionsUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.logout.Perform(context.Background(), dtos.SessionDto{Id: 1, TokenId: "token id", ExpireIn: time.Now().Add(time.Hour)})
+
+	assert.NoError(t, err)
+}
+
+func Test_LogoutUC_Should_Return_Unauthorized_If_Token_Has_No_Identifier(t *testing.T) {
+	sut := newRevokeSessionsUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.logout.Perform(context.Background(), dtos.SessionDto{Id: 1})
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_LogoutUC_Should_Return_InternalError_If_Some_Error_Occur_In_Denylist(t *testing.T) {
+	config := map[string]mockConfigure{
+		"denylist": {
+			method:      "Add",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newRevokeSessionsUsecaseToTest(config)
+
+	err := sut.logout.Perform(context.Background(), dtos.SessionDto{Id: 1, TokenId: "token id"})
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
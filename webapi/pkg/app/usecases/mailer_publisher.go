@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"webapi/pkg/app/interfaces"
+)
+
+type mailerPublisher struct {
+	messageBroker interfaces.IMessageBroker
+}
+
+// Publish hands an event over to the mailer service through its queue.
+func (pst mailerPublisher) Publish(ctx context.Context, event interface{}) error {
+	queue := os.Getenv("AMQP_MAILER_QUEUE")
+	exchange := os.Getenv("AMQP_MAILER_EXCHANGE")
+	kind := os.Getenv("AMQP_MAILER_EXCHANGE_KIND")
+	routingKey := os.Getenv("AMQP_MAILER_ROUTING_KEY")
+	deadLetterExchange := os.Getenv("DEAD_LETTER_EXCHANGE")
+	deadLetterRoutingKey := os.Getenv("DEAD_LETTER_ROUTING_KEY")
+
+	return pst.messageBroker.Publisher(ctx, exchange, kind, queue, routingKey, deadLetterExchange, deadLetterRoutingKey, event, nil)
+}
+
+func newMailerPublisher(messageBroker interfaces.IMessageBroker) mailerPublisher {
+	return mailerPublisher{messageBroker}
+}
@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pendingMfa() *entities.UserMfa {
+	return &entities.UserMfa{UserId: 1, Secret: "SECRET"}
+}
+
+func enabledMfa() *entities.UserMfa {
+	enabledAt := time.Now()
+	return &entities.UserMfa{UserId: 1, Secret: "SECRET", EnabledAt: &enabledAt}
+}
+
+func Test_EnrollMfaUC_Should_Return_Provisioning_Uri(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: &entities.User{Id: 1, Email: "some@email.com"},
+		},
+	}
+	t.Setenv("APP_ISSUER", "GoWebApi")
+
+	sut := newMfaUsecaseToTest(config)
+
+	result, err := sut.enroll.Perform(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRET", result.Secret)
+	assert.Equal(t, "otpauth://totp/GoWebApi:some@email.com?secret=SECRET", result.ProvisioningUri)
+}
+
+func Test_EnrollMfaUC_Should_Return_Conflict_If_Already_Enabled(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa": {customResult: enabledMfa()},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.enroll.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.ConflictError{})
+}
+
+func Test_EnrollMfaUC_Should_Return_Error_If_Secret_Could_Not_Be_Saved(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfaRepository": {
+			method:      "SavePending",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.enroll.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_ConfirmMfaUC_Should_Enable_And_Return_Recovery_Codes(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa": {customResult: pendingMfa()},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	codes, err := sut.confirm.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "123456"})
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, mfaRecoveryCodesCount)
+}
+
+func Test_ConfirmMfaUC_Should_Return_BadRequest_Without_Enrollment(t *testing.T) {
+	sut := newMfaUsecaseToTest(map[string]mockConfigure{})
+
+	_, err := sut.confirm.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "123456"})
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_ConfirmMfaUC_Should_Return_BadRequest_If_Code_Is_Wrong(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":  {customResult: pendingMfa()},
+		"totp": {method: "Verify", customResult: false},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.confirm.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "000000"})
+
+	assert.EqualError(t, err, "Invalid two-factor code")
+}
+
+func Test_ConfirmMfaUC_Should_Reject_Replayed_Code(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":           {customResult: pendingMfa()},
+		"mfaRepository": {method: "MarkStepUsed", customResult: false},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.confirm.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "123456"})
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_DisableMfaUC_Should_Disable_With_Recovery_Code(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":           {customResult: enabledMfa()},
+		"totp":          {method: "Verify", customResult: false},
+		"mfaRepository": {method: "ConsumeRecoveryCode", customResult: true},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	err := sut.disable.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "AAAA-BBBB-CCCC-DDDD"})
+
+	assert.NoError(t, err)
+}
+
+func Test_DisableMfaUC_Should_Return_BadRequest_If_Not_Enabled(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa": {customResult: pendingMfa()},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	err := sut.disable.Perform(context.Background(), dtos.MfaCodeDto{UserId: 1, Code: "123456"})
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_VerifyMfaUC_Should_Issue_Session(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":          {customResult: enabledMfa()},
+		"tokenManager": {method: "VerifyToken", customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseMfaPending}},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	result, err := sut.verify.Perform(context.Background(), dtos.MfaChallengeDto{MfaToken: "mfa token", Code: "123456"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, dtos.TokenUseAccess, result.Use)
+	assert.NotEmpty(t, result.RefreshToken)
+}
+
+func Test_VerifyMfaUC_Should_Reject_Access_Tokens(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":          {customResult: enabledMfa()},
+		"tokenManager": {method: "VerifyToken", customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseAccess}},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.verify.Perform(context.Background(), dtos.MfaChallengeDto{MfaToken: "access token", Code: "123456"})
+
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_VerifyMfaUC_Should_Return_Unauthorized_If_Code_Is_Wrong(t *testing.T) {
+	config := map[string]mockConfigure{
+		"mfa":          {customResult: enabledMfa()},
+		"totp":         {method: "Verify", customResult: false},
+		"tokenManager": {method: "VerifyToken", customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseMfaPending}},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.verify.Perform(context.Background(), dtos.MfaChallengeDto{MfaToken: "mfa token", Code: "000000"})
+
+	assert.EqualError(t, err, "Invalid two-factor code")
+}
+
+func Test_VerifyMfaUC_Should_Return_TooManyRequests_While_Locked_Out(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Minute)
+	config := map[string]mockConfigure{
+		"mfa":               {customResult: enabledMfa()},
+		"tokenManager":      {method: "VerifyToken", customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseMfaPending}},
+		"loginAttemptStore": {method: "Find", customResult: &entities.LoginAttempt{Key: "mfa:1", LockedUntil: &lockedUntil}},
+	}
+
+	sut := newMfaUsecaseToTest(config)
+
+	_, err := sut.verify.Perform(context.Background(), dtos.MfaChallengeDto{MfaToken: "mfa token", Code: "123456"})
+
+	assert.IsType(t, err, internalError.TooManyRequestsError{})
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+)
+
+const mfaRecoveryCodesCount = 10
+
+type mfaVerifier struct {
+	repository   interfaces.IMfaRepository
+	totp         interfaces.ITotp
+	tokenManager interfaces.ITokenManager
+	throttler    loginThrottler
+}
+
+// Verify accepts a current TOTP code or, when allowed, an unused recovery code.
+// Both are burned on success. Failures count against the same lockout as
+// passwords so the 6 digit space cannot be brute forced.
+func (pst mfaVerifier) Verify(ctx context.Context, mfa entities.UserMfa, code, clientIp string, allowRecovery bool) (bool, error) {
+	keys := attemptKeys(mfaAttemptKey(mfa.UserId), clientIp)
+	if err := pst.throttler.Check(ctx, keys...); err != nil {
+		return false, err
+	}
+
+	ok, err := pst.check(ctx, mfa, code, allowRecovery)
+	if err != nil {
+		return false, errors.NewInternalError(err.Error())
+	}
+
+	if !ok {
+		return false, pst.throttler.RegisterFailure(ctx, keys...)
+	}
+
+	return true, pst.throttler.RegisterSuccess(ctx, keys[0])
+}
+
+func (pst mfaVerifier) check(ctx context.Context, mfa entities.UserMfa, code string, allowRecovery bool) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if step, ok := pst.totp.Verify(mfa.Secret, code); ok {
+		return pst.repository.MarkStepUsed(ctx, mfa.UserId, step)
+	}
+
+	if !allowRecovery {
+		return false, nil
+	}
+
+	return pst.repository.ConsumeRecoveryCode(ctx, mfa.UserId, pst.hashRecoveryCode(code))
+}
+
+// hashRecoveryCode hashes the canonical form of a code, so dashes, spaces and
+// letter case typed by the user do not matter.
+func (pst mfaVerifier) hashRecoveryCode(code string) string {
+	canonical := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return pst.tokenManager.HashOpaqueToken(canonical)
+}
+
+func newMfaVerifier(
+	repository interfaces.IMfaRepository,
+	totp interfaces.ITotp,
+	tokenManager interfaces.ITokenManager,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	logger interfaces.ILogger,
+) mfaVerifier {
+	return mfaVerifier{
+		repository,
+		totp,
+		tokenManager,
+		newLoginThrottler(loginAttemptStore, logger),
+	}
+}
@@ -2,6 +2,9 @@ package usecases
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/entities"
@@ -9,6 +12,11 @@ import (
 	"webapi/pkg/infra/logger"
 )
 
+// outdatedPasswordHash is the stored hash hasherSpy reports as needing rehash.
+const outdatedPasswordHash = "outdated hash"
+
+const userEmailToTest = "email@email.com"
+
 type mockConfigure struct {
 	method       string
 	customResult interface{}
@@ -47,15 +55,48 @@ func newCreateUserUseCaseToTest(configs map[string]mockConfigure) createUserUseC
 		tokenManager = tokenManagerSpy{}
 	}
 
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	roleRepositoryConfig, ok := configs["roleRepository"]
+	var roleRepository interfaces.IRoleRepository
+	if ok {
+		roleRepository = roleRepositorySpy{config: &roleRepositoryConfig}
+	} else {
+		roleRepository = roleRepositorySpy{}
+	}
+
+	messageBrokerConfig, ok := configs["messageBroker"]
+	var messageBroker interfaces.IMessageBroker
+	if ok {
+		messageBroker = messageBrokerSpy{config: &messageBrokerConfig}
+	} else {
+		messageBroker = messageBrokerSpy{}
+	}
+
 	logger := logger.NewLoggerSpy()
 
-	useCase := NewCreateUserUseCase(repo, hasher, tokenManager)
+	transactionConfig, ok := configs["transaction"]
+	var transaction interfaces.ITransactionManager
+	if ok {
+		transaction = transactionManagerSpy{config: &transactionConfig}
+	} else {
+		transaction = transactionManagerSpy{}
+	}
+
+	useCase := NewCreateUserUseCase(repo, sessionRepository, roleRepository, transaction, hasher, tokenManager, messageBroker, logger)
 	return createUserUseCaseToTest{useCase, repo, hasher, tokenManager, logger}
 }
 
 type validationTokenUseCaseToTest struct {
 	useCase      usecases.IValidationTokenUseCase
 	repo         interfaces.IUserRepository
+	denylist     interfaces.ITokenDenylistRepository
 	tokenManager interfaces.ITokenManager
 }
 
@@ -76,8 +117,16 @@ func newValidationTokenUseCaseToTest(configs map[string]mockConfigure) validatio
 		tokenManager = tokenManagerSpy{}
 	}
 
-	useCase := NewValidatinTokenUseCase(repo, tokenManager)
-	return validationTokenUseCaseToTest{useCase, repo, tokenManager}
+	denylistConfig, ok := configs["denylist"]
+	var denylist interfaces.ITokenDenylistRepository
+	if ok {
+		denylist = tokenDenylistSpy{config: &denylistConfig}
+	} else {
+		denylist = tokenDenylistSpy{}
+	}
+
+	useCase := NewValidatinTokenUseCase(repo, denylist, tokenManager)
+	return validationTokenUseCaseToTest{useCase, repo, denylist, tokenManager}
 }
 
 type sessionUsecaseToTest struct {
@@ -85,6 +134,7 @@ type sessionUsecaseToTest struct {
 	repo         interfaces.IUserRepository
 	hasher       interfaces.IHasher
 	tokenManager interfaces.ITokenManager
+	logger       interfaces.ILogger
 }
 
 func newSessionUsecaseToTest(configs map[string]mockConfigure) sessionUsecaseToTest {
@@ -112,10 +162,366 @@ func newSessionUsecaseToTest(configs map[string]mockConfigure) sessionUsecaseToT
 		tokenManager = tokenManagerSpy{}
 	}
 
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	roleRepositoryConfig, ok := configs["roleRepository"]
+	var roleRepository interfaces.IRoleRepository
+	if ok {
+		roleRepository = roleRepositorySpy{config: &roleRepositoryConfig}
+	} else {
+		roleRepository = roleRepositorySpy{}
+	}
+
+	loginAttemptStoreConfig, ok := configs["loginAttemptStore"]
+	var loginAttemptStore interfaces.ILoginAttemptStore
+	if ok {
+		loginAttemptStore = loginAttemptStoreSpy{config: &loginAttemptStoreConfig}
+	} else {
+		loginAttemptStore = loginAttemptStoreSpy{}
+	}
+
+	logger := logger.NewLoggerSpy()
+
+	mfaRepository := newMfaRepositorySpy(configs)
+
+	useCase := NewSessionUseCase(repo, sessionRepository, roleRepository, mfaRepository, loginAttemptStore, hasher, tokenManager, logger)
+	return sessionUsecaseToTest{useCase, repo, hasher, tokenManager, logger}
+}
+
+type refreshSessionUsecaseToTest struct {
+	useCase           usecases.IRefreshSessionUseCase
+	repo              interfaces.IUserRepository
+	sessionRepository interfaces.ISessionRepository
+	tokenManager      interfaces.ITokenManager
+}
+
+func newRefreshSessionUsecaseToTest(configs map[string]mockConfigure) refreshSessionUsecaseToTest {
+	repoConfig, ok := configs["userRepository"]
+	var repo interfaces.IUserRepository
+	if ok {
+		repo = userRepositorySpy{config: &repoConfig}
+	} else {
+		repo = userRepositorySpy{}
+	}
+
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	tokenManagerConfig, ok := configs["tokenManager"]
+	var tokenManager interfaces.ITokenManager
+	if ok {
+		tokenManager = tokenManagerSpy{config: &tokenManagerConfig}
+	} else {
+		tokenManager = tokenManagerSpy{}
+	}
+
+	roleRepositoryConfig, ok := configs["roleRepository"]
+	var roleRepository interfaces.IRoleRepository
+	if ok {
+		roleRepository = roleRepositorySpy{config: &roleRepositoryConfig}
+	} else {
+		roleRepository = roleRepositorySpy{}
+	}
+
 	logger := logger.NewLoggerSpy()
 
-	useCase := NewSessionUseCase(repo, hasher, tokenManager, logger)
-	return sessionUsecaseToTest{useCase, repo, hasher, tokenManager}
+	transactionConfig, ok := configs["transaction"]
+	var transaction interfaces.ITransactionManager
+	if ok {
+		transaction = transactionManagerSpy{config: &transactionConfig}
+	} else {
+		transaction = transactionManagerSpy{}
+	}
+
+	useCase := NewRefreshSessionUseCase(repo, sessionRepository, roleRepository, transaction, tokenManager, logger)
+	return refreshSessionUsecaseToTest{useCase, repo, sessionRepository, tokenManager}
+}
+
+type revokeSessionsUsecaseToTest struct {
+	logout         usecases.ILogoutUseCase
+	revokeSessions usecases.IRevokeUserSessionsUseCase
+}
+
+func newRevokeSessionsUsecaseToTest(configs map[string]mockConfigure) revokeSessionsUsecaseToTest {
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	denylistConfig, ok := configs["denylist"]
+	var denylist interfaces.ITokenDenylistRepository
+	if ok {
+		denylist = tokenDenylistSpy{config: &denylistConfig}
+	} else {
+		denylist = tokenDenylistSpy{}
+	}
+
+	return revokeSessionsUsecaseToTest{
+		NewLogoutUseCase(sessionRepository, denylist),
+		NewRevokeUserSessionsUseCase(sessionRepository, denylist),
+	}
+}
+
+type emailVerificationUsecaseToTest struct {
+	verify  usecases.IVerifyEmailUseCase
+	request usecases.IRequestEmailVerificationUseCase
+}
+
+func newEmailVerificationUsecaseToTest(configs map[string]mockConfigure) emailVerificationUsecaseToTest {
+	repoConfig, ok := configs["userRepository"]
+	var repo interfaces.IUserRepository
+	if ok {
+		repo = userRepositorySpy{config: &repoConfig}
+	} else {
+		repo = userRepositorySpy{}
+	}
+
+	tokenManagerConfig, ok := configs["tokenManager"]
+	var tokenManager interfaces.ITokenManager
+	if ok {
+		tokenManager = tokenManagerSpy{config: &tokenManagerConfig}
+	} else {
+		tokenManager = tokenManagerSpy{}
+	}
+
+	messageBrokerConfig, ok := configs["messageBroker"]
+	var messageBroker interfaces.IMessageBroker
+	if ok {
+		messageBroker = messageBrokerSpy{config: &messageBrokerConfig}
+	} else {
+		messageBroker = messageBrokerSpy{}
+	}
+
+	denylistConfig, ok := configs["denylist"]
+	var denylist interfaces.ITokenDenylistRepository
+	if ok {
+		denylist = tokenDenylistSpy{config: &denylistConfig}
+	} else {
+		denylist = tokenDenylistSpy{}
+	}
+
+	return emailVerificationUsecaseToTest{
+		NewVerifyEmailUseCase(repo, tokenManager, denylist),
+		NewRequestEmailVerificationUseCase(repo, tokenManager, messageBroker),
+	}
+}
+
+type mfaUsecaseToTest struct {
+	enroll  usecases.IEnrollMfaUseCase
+	confirm usecases.IConfirmMfaUseCase
+	disable usecases.IDisableMfaUseCase
+	verify  usecases.IVerifyMfaUseCase
+}
+
+func newMfaUsecaseToTest(configs map[string]mockConfigure) mfaUsecaseToTest {
+	repoConfig, ok := configs["userRepository"]
+	var repo interfaces.IUserRepository
+	if ok {
+		repo = userRepositorySpy{config: &repoConfig}
+	} else {
+		repo = userRepositorySpy{}
+	}
+
+	loginAttemptStoreConfig, ok := configs["loginAttemptStore"]
+	var loginAttemptStore interfaces.ILoginAttemptStore
+	if ok {
+		loginAttemptStore = loginAttemptStoreSpy{config: &loginAttemptStoreConfig}
+	} else {
+		loginAttemptStore = loginAttemptStoreSpy{}
+	}
+
+	totpConfig, ok := configs["totp"]
+	var totp interfaces.ITotp
+	if ok {
+		totp = totpSpy{config: &totpConfig}
+	} else {
+		totp = totpSpy{}
+	}
+
+	tokenManagerConfig, ok := configs["tokenManager"]
+	var tokenManager interfaces.ITokenManager
+	if ok {
+		tokenManager = tokenManagerSpy{config: &tokenManagerConfig}
+	} else {
+		tokenManager = tokenManagerSpy{}
+	}
+
+	mfaRepository := newMfaRepositorySpy(configs)
+	sessionRepository := sessionRepositorySpy{}
+	roleRepository := roleRepositorySpy{}
+	logger := logger.NewLoggerSpy()
+
+	return mfaUsecaseToTest{
+		NewEnrollMfaUseCase(repo, mfaRepository, totp),
+		NewConfirmMfaUseCase(mfaRepository, loginAttemptStore, totp, tokenManager, logger),
+		NewDisableMfaUseCase(mfaRepository, loginAttemptStore, totp, tokenManager, logger),
+		NewVerifyMfaUseCase(mfaRepository, sessionRepository, roleRepository, loginAttemptStore, totp, tokenManager, logger),
+	}
+}
+
+type userProfileUsecaseToTest struct {
+	get            usecases.IGetUserProfileUseCase
+	update         usecases.IUpdateUserProfileUseCase
+	changePassword usecases.IChangePasswordUseCase
+	delete         usecases.IDeleteUserUseCase
+	logger         interfaces.ILogger
+}
+
+func newUserProfileUsecaseToTest(configs map[string]mockConfigure) userProfileUsecaseToTest {
+	repoConfig, ok := configs["userRepository"]
+	var repo interfaces.IUserRepository
+	if ok {
+		repo = userRepositorySpy{config: &repoConfig}
+	} else {
+		repo = userRepositorySpy{}
+	}
+
+	hasherConfig, ok := configs["hasher"]
+	var hasher interfaces.IHasher
+	if ok {
+		hasher = hasherSpy{config: &hasherConfig}
+	} else {
+		hasher = hasherSpy{}
+	}
+
+	loginAttemptStoreConfig, ok := configs["loginAttemptStore"]
+	var loginAttemptStore interfaces.ILoginAttemptStore
+	if ok {
+		loginAttemptStore = loginAttemptStoreSpy{config: &loginAttemptStoreConfig}
+	} else {
+		loginAttemptStore = loginAttemptStoreSpy{}
+	}
+
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	messageBrokerConfig, ok := configs["messageBroker"]
+	var messageBroker interfaces.IMessageBroker
+	if ok {
+		messageBroker = messageBrokerSpy{config: &messageBrokerConfig}
+	} else {
+		messageBroker = messageBrokerSpy{}
+	}
+
+	denylist := tokenDenylistSpy{}
+	tokenManager := tokenManagerSpy{}
+	logger := logger.NewLoggerSpy()
+
+	return userProfileUsecaseToTest{
+		NewGetUserProfileUseCase(repo),
+		NewUpdateUserProfileUseCase(repo, tokenManager, messageBroker, logger),
+		NewChangePasswordUseCase(repo, sessionRepository, denylist, loginAttemptStore, hasher, logger),
+		NewDeleteUserUseCase(repo, sessionRepository, denylist),
+		logger,
+	}
+}
+
+type passwordResetUsecaseToTest struct {
+	request usecases.IRequestPasswordResetUseCase
+	confirm usecases.IConfirmPasswordResetUseCase
+}
+
+func newPasswordResetUsecaseToTest(configs map[string]mockConfigure) passwordResetUsecaseToTest {
+	repoConfig, ok := configs["userRepository"]
+	var repo interfaces.IUserRepository
+	if ok {
+		repo = userRepositorySpy{config: &repoConfig}
+	} else {
+		repo = userRepositorySpy{}
+	}
+
+	passwordResetRepositoryConfig, ok := configs["passwordResetRepository"]
+	var passwordResetRepository interfaces.IPasswordResetRepository
+	if ok {
+		passwordResetRepository = passwordResetRepositorySpy{config: &passwordResetRepositoryConfig}
+	} else {
+		passwordResetRepository = passwordResetRepositorySpy{}
+	}
+
+	sessionRepositoryConfig, ok := configs["sessionRepository"]
+	var sessionRepository interfaces.ISessionRepository
+	if ok {
+		sessionRepository = sessionRepositorySpy{config: &sessionRepositoryConfig}
+	} else {
+		sessionRepository = sessionRepositorySpy{}
+	}
+
+	denylistConfig, ok := configs["denylist"]
+	var denylist interfaces.ITokenDenylistRepository
+	if ok {
+		denylist = tokenDenylistSpy{config: &denylistConfig}
+	} else {
+		denylist = tokenDenylistSpy{}
+	}
+
+	hasherConfig, ok := configs["hasher"]
+	var hasher interfaces.IHasher
+	if ok {
+		hasher = hasherSpy{config: &hasherConfig}
+	} else {
+		hasher = hasherSpy{}
+	}
+
+	messageBrokerConfig, ok := configs["messageBroker"]
+	var messageBroker interfaces.IMessageBroker
+	if ok {
+		messageBroker = messageBrokerSpy{config: &messageBrokerConfig}
+	} else {
+		messageBroker = messageBrokerSpy{}
+	}
+
+	tokenManager := tokenManagerSpy{}
+
+	return passwordResetUsecaseToTest{
+		NewRequestPasswordResetUseCase(repo, passwordResetRepository, tokenManager, messageBroker),
+		NewConfirmPasswordResetUseCase(repo, passwordResetRepository, sessionRepository, denylist, hasher, tokenManager),
+	}
+}
+
+type passwordResetRepositorySpy struct {
+	config *mockConfigure
+}
+
+func (pst passwordResetRepositorySpy) Create(ctx context.Context, dto dtos.CreatePasswordResetDto) error {
+	if pst.config != nil && pst.config.method == "Create" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst passwordResetRepositorySpy) Consume(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error) {
+	if pst.config != nil && pst.config.method == "Consume" {
+		reset, _ := pst.config.customResult.(*entities.PasswordResetToken)
+		return reset, pst.config.customError
+	}
+
+	return &entities.PasswordResetToken{UserId: 1}, nil
+}
+func (pst passwordResetRepositorySpy) InvalidateByUserId(ctx context.Context, userId int) error {
+	if pst.config != nil && pst.config.method == "InvalidateByUserId" {
+		return pst.config.customError
+	}
+
+	return nil
 }
 
 type userRepositorySpy struct {
@@ -131,7 +537,7 @@ func (pst userRepositorySpy) FindById(ctx context.Context, id int) (*entities.Us
 		return nil, pst.config.customError
 	}
 
-	return &entities.User{}, nil
+	return &entities.User{Email: userEmailToTest}, nil
 }
 func (pst userRepositorySpy) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	if pst.config != nil && pst.config.method == "FindByEmail" {
@@ -156,6 +562,153 @@ func (pst userRepositorySpy) Create(ctx context.Context, dto dtos.CreateUserDto)
 
 	return &entities.User{}, nil
 }
+func (pst userRepositorySpy) MarkEmailAsVerified(ctx context.Context, id int) error {
+	if pst.config != nil && pst.config.method == "MarkEmailAsVerified" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst userRepositorySpy) UpdatePassword(ctx context.Context, id int, password string) error {
+	if pst.config != nil && pst.config.method == "UpdatePassword" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst userRepositorySpy) Update(ctx context.Context, id int, dto dtos.UpdateUserDto) (*entities.User, error) {
+	if pst.config != nil && pst.config.method == "Update" {
+		user, ok := pst.config.customResult.(*entities.User)
+		if ok {
+			return user, pst.config.customError
+		}
+		return nil, pst.config.customError
+	}
+
+	return &entities.User{Id: id, Name: dto.Name, Email: dto.Email}, nil
+}
+func (pst userRepositorySpy) SoftDelete(ctx context.Context, id int) error {
+	if pst.config != nil && pst.config.method == "SoftDelete" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+type roleRepositorySpy struct {
+	config *mockConfigure
+}
+
+func (pst roleRepositorySpy) FindByUserId(ctx context.Context, userId int) ([]entities.Role, error) {
+	if pst.config != nil && pst.config.method == "FindByUserId" {
+		roles, _ := pst.config.customResult.([]entities.Role)
+		return roles, pst.config.customError
+	}
+
+	return []entities.Role{}, nil
+}
+func (pst roleRepositorySpy) AssignToUser(ctx context.Context, userId int, roleName string) error {
+	if pst.config != nil && pst.config.method == "AssignToUser" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+type transactionManagerSpy struct {
+	config *mockConfigure
+}
+
+func (pst transactionManagerSpy) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if pst.config != nil && pst.config.method == "WithinTransaction" {
+		return pst.config.customError
+	}
+
+	return fn(ctx)
+}
+
+type sessionRepositorySpy struct {
+	config *mockConfigure
+}
+
+func (pst sessionRepositorySpy) Create(ctx context.Context, dto dtos.CreateSessionDto) (*entities.Session, error) {
+	if pst.config != nil && pst.config.method == "Create" {
+		session, ok := pst.config.customResult.(*entities.Session)
+		if ok {
+			return session, pst.config.customError
+		}
+		return nil, pst.config.customError
+	}
+
+	return &entities.Session{}, nil
+}
+func (pst sessionRepositorySpy) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error) {
+	if pst.config != nil && pst.config.method == "FindByTokenHash" {
+		session, ok := pst.config.customResult.(*entities.Session)
+		if ok {
+			return session, pst.config.customError
+		}
+		return nil, pst.config.customError
+	}
+
+	return &entities.Session{Id: 1, UserId: 1, FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+func (pst sessionRepositorySpy) FindLiveByUserId(ctx context.Context, userId int) ([]entities.Session, error) {
+	if pst.config != nil && pst.config.method == "FindLiveByUserId" {
+		sessions, _ := pst.config.customResult.([]entities.Session)
+		return sessions, pst.config.customError
+	}
+
+	return []entities.Session{{Id: 1, UserId: userId, AccessTokenId: "token id", AccessExpiresAt: time.Now().Add(time.Hour)}}, nil
+}
+func (pst sessionRepositorySpy) MarkAsRotated(ctx context.Context, id int) (bool, error) {
+	if pst.config != nil && pst.config.method == "MarkAsRotated" {
+		return pst.config.customResult.(bool), pst.config.customError
+	}
+
+	return true, nil
+}
+func (pst sessionRepositorySpy) RevokeFamily(ctx context.Context, familyId string) error {
+	if pst.config != nil && pst.config.method == "RevokeFamily" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+func (pst sessionRepositorySpy) RevokeByAccessTokenId(ctx context.Context, accessTokenId string) error {
+	if pst.config != nil && pst.config.method == "RevokeByAccessTokenId" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst sessionRepositorySpy) RevokeAllByUserId(ctx context.Context, userId int) error {
+	if pst.config != nil && pst.config.method == "RevokeAllByUserId" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+type tokenDenylistSpy struct {
+	config *mockConfigure
+}
+
+func (pst tokenDenylistSpy) Add(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	if pst.config != nil && pst.config.method == "Add" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst tokenDenylistSpy) Contains(ctx context.Context, tokenId string, expiresAt time.Time) (bool, error) {
+	if pst.config != nil && pst.config.method == "Contains" {
+		return pst.config.customResult.(bool), pst.config.customError
+	}
+
+	return false, nil
+}
 
 type hasherSpy struct {
 	config *mockConfigure
@@ -175,6 +728,144 @@ func (pst hasherSpy) Verify(originalText, hashedText string) bool {
 
 	return true
 }
+func (pst hasherSpy) NeedsRehash(hashedText string) bool {
+	return hashedText == outdatedPasswordHash
+}
+
+type loginAttemptStoreSpy struct {
+	config *mockConfigure
+}
+
+func (pst loginAttemptStoreSpy) Find(ctx context.Context, key string) (*entities.LoginAttempt, error) {
+	if pst.config != nil && pst.config.method == "Find" {
+		attempt, _ := pst.config.customResult.(*entities.LoginAttempt)
+		return attempt, pst.config.customError
+	}
+
+	return nil, nil
+}
+func (pst loginAttemptStoreSpy) RegisterFailure(ctx context.Context, key string, window time.Duration) (entities.LoginAttempt, error) {
+	if pst.config != nil && pst.config.method == "RegisterFailure" {
+		failures, _ := pst.config.customResult.(int)
+		return entities.LoginAttempt{Key: key, Failures: failures, LastFailureAt: time.Now()}, pst.config.customError
+	}
+
+	return entities.LoginAttempt{Key: key, Failures: 1, LastFailureAt: time.Now()}, nil
+}
+func (pst loginAttemptStoreSpy) Lock(ctx context.Context, key string, until time.Time) error {
+	if pst.config != nil && pst.config.method == "Lock" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst loginAttemptStoreSpy) Reset(ctx context.Context, key string) error {
+	if pst.config != nil && pst.config.method == "Reset" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+// mfaRepositorySpy returns the enrollment configured under "mfa" from
+// FindByUserId, so "mfaRepository" stays free to override another method.
+type mfaRepositorySpy struct {
+	config *mockConfigure
+	mfa    *entities.UserMfa
+}
+
+func newMfaRepositorySpy(configs map[string]mockConfigure) mfaRepositorySpy {
+	spy := mfaRepositorySpy{}
+
+	if config, ok := configs["mfaRepository"]; ok {
+		spy.config = &config
+	}
+
+	if config, ok := configs["mfa"]; ok {
+		spy.mfa, _ = config.customResult.(*entities.UserMfa)
+	}
+
+	return spy
+}
+
+func (pst mfaRepositorySpy) FindByUserId(ctx context.Context, userId int) (*entities.UserMfa, error) {
+	if pst.config != nil && pst.config.method == "FindByUserId" {
+		return nil, pst.config.customError
+	}
+
+	return pst.mfa, nil
+}
+func (pst mfaRepositorySpy) SavePending(ctx context.Context, userId int, secret string) error {
+	if pst.config != nil && pst.config.method == "SavePending" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst mfaRepositorySpy) Enable(ctx context.Context, userId int, recoveryCodeHashes []string) error {
+	if pst.config != nil && pst.config.method == "Enable" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+func (pst mfaRepositorySpy) MarkStepUsed(ctx context.Context, userId int, step int64) (bool, error) {
+	if pst.config != nil && pst.config.method == "MarkStepUsed" {
+		used, _ := pst.config.customResult.(bool)
+		return used, pst.config.customError
+	}
+
+	return true, nil
+}
+func (pst mfaRepositorySpy) ConsumeRecoveryCode(ctx context.Context, userId int, codeHash string) (bool, error) {
+	if pst.config != nil && pst.config.method == "ConsumeRecoveryCode" {
+		consumed, _ := pst.config.customResult.(bool)
+		return consumed, pst.config.customError
+	}
+
+	return false, nil
+}
+func (pst mfaRepositorySpy) Delete(ctx context.Context, userId int) error {
+	if pst.config != nil && pst.config.method == "Delete" {
+		return pst.config.customError
+	}
+
+	return nil
+}
+
+type totpSpy struct {
+	config *mockConfigure
+}
+
+func (pst totpSpy) GenerateSecret() (string, error) {
+	if pst.config != nil && pst.config.method == "GenerateSecret" {
+		return "", pst.config.customError
+	}
+
+	return "SECRET", nil
+}
+func (pst totpSpy) ProvisioningUri(secret, issuer, accountName string) string {
+	return "otpauth://totp/" + issuer + ":" + accountName + "?secret=" + secret
+}
+func (pst totpSpy) Verify(secret, code string) (int64, bool) {
+	if pst.config != nil && pst.config.method == "Verify" {
+		return 1, pst.config.customResult.(bool)
+	}
+
+	return 1, true
+}
+func (pst totpSpy) GenerateRecoveryCodes(count int) ([]string, error) {
+	if pst.config != nil && pst.config.method == "GenerateRecoveryCodes" {
+		return nil, pst.config.customError
+	}
+
+	codes := make([]string, count)
+	for i := range codes {
+		codes[i] = "aaaa-bbbb-cccc-dddd"
+	}
+
+	return codes, nil
+}
 
 type tokenManagerSpy struct {
 	config *mockConfigure
@@ -194,22 +885,60 @@ func (pst tokenManagerSpy) VerifyToken(token string) (*dtos.SessionDto, error) {
 
 	return &dtos.SessionDto{}, nil
 }
+func (pst tokenManagerSpy) GenerateOpaqueToken() (string, error) {
+	if pst.config != nil && pst.config.method == "GenerateOpaqueToken" {
+		return pst.config.customResult.(string), pst.config.customError
+	}
+
+	return "opaque token", nil
+}
+func (pst tokenManagerSpy) HashOpaqueToken(token string) string {
+	return token
+}
+func (pst tokenManagerSpy) JsonWebKeys() []dtos.JsonWebKeyDto {
+	if pst.config != nil && pst.config.method == "JsonWebKeys" {
+		return pst.config.customResult.([]dtos.JsonWebKeyDto)
+	}
+
+	return []dtos.JsonWebKeyDto{}
+}
+func (pst tokenManagerSpy) Close() error {
+	return nil
+}
+
+type getJsonWebKeysUsecaseToTest struct {
+	useCase usecases.IGetJsonWebKeysUseCase
+}
+
+func newGetJsonWebKeysUsecaseToTest(configs map[string]mockConfigure) getJsonWebKeysUsecaseToTest {
+	tokenManagerConfig, ok := configs["tokenManager"]
+	var tokenManager interfaces.ITokenManager
+	if ok {
+		tokenManager = tokenManagerSpy{config: &tokenManagerConfig}
+	} else {
+		tokenManager = tokenManagerSpy{}
+	}
+
+	useCase := NewGetJsonWebKeysUseCase(tokenManager)
+	return getJsonWebKeysUsecaseToTest{useCase}
+}
 
 type getProductByIdUsecaseToTest struct {
-	useCase usecases.IGetProductByIdUseCase
+	useCase         usecases.IGetProductByIdUseCase
+	inventoryClient *countingInventoryClientSpy
+	cache           *cacheSpy
 }
 
 func newGetProductByIdUsecaseToTest(configs map[string]mockConfigure) getProductByIdUsecaseToTest {
 	inventoryClientConfig, ok := configs["inventoryClient"]
-	var inventoryClient interfaces.IIventoryClient
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
 	if ok {
-		inventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
-	} else {
-		inventoryClient = inventoryClientSpy{}
+		inventoryClient.IIventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
 	}
+	cache := newCacheSpy()
 
-	useCase := NewGetProductByIdUseCase(inventoryClient)
-	return getProductByIdUsecaseToTest{useCase}
+	useCase := NewGetProductByIdUseCase(inventoryClient, cache)
+	return getProductByIdUsecaseToTest{useCase, inventoryClient, cache}
 }
 
 type inventoryClientSpy struct {
@@ -224,6 +953,30 @@ func (pst inventoryClientSpy) GetProductById(ctx context.Context, id string) (dt
 	return dtos.ProductDto{}, nil
 }
 
+func (pst inventoryClientSpy) GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	if pst.config != nil && pst.config.method == "GetProducts" {
+		return pst.config.customResult.(dtos.ProductsPageDto), pst.config.customError
+	}
+
+	return dtos.ProductsPageDto{Products: []dtos.ProductDto{}, Limit: limit, Offset: offset}, nil
+}
+
+func (pst inventoryClientSpy) GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	if pst.config != nil && pst.config.method == "GetProductsByType" {
+		return pst.config.customResult.([]dtos.ProductDto), pst.config.customError
+	}
+
+	return []dtos.ProductDto{}, nil
+}
+
+func (pst inventoryClientSpy) UpdateProduct(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	if pst.config != nil && pst.config.method == "UpdateProduct" {
+		return pst.config.customResult.(dtos.ProductDto), pst.config.customError
+	}
+
+	return dtos.ProductDto{Id: dto.Id}, nil
+}
+
 func (pst inventoryClientSpy) RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error) {
 	if pst.config != nil && pst.config.method == "RegisterProduct" {
 		return pst.config.customResult.(dtos.ProductDto), pst.config.customError
@@ -232,21 +985,149 @@ func (pst inventoryClientSpy) RegisterProduct(ctx context.Context, product dtos.
 	return dtos.ProductDto{}, nil
 }
 
+type getProductsUsecaseToTest struct {
+	useCase         usecases.IGetProductsUseCase
+	inventoryClient *countingInventoryClientSpy
+	cache           *cacheSpy
+}
+
+func newGetProductsUsecaseToTest(configs map[string]mockConfigure) getProductsUsecaseToTest {
+	inventoryClientConfig, ok := configs["inventoryClient"]
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
+	if ok {
+		inventoryClient.IIventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
+	}
+	cache := newCacheSpy()
+
+	useCase := NewGetProductsUseCase(inventoryClient, cache)
+	return getProductsUsecaseToTest{useCase, inventoryClient, cache}
+}
+
+type getProductsByTypeUsecaseToTest struct {
+	useCase         usecases.IGetProductsByTypeUseCase
+	inventoryClient *countingInventoryClientSpy
+	cache           *cacheSpy
+}
+
+func newGetProductsByTypeUsecaseToTest(configs map[string]mockConfigure) getProductsByTypeUsecaseToTest {
+	inventoryClientConfig, ok := configs["inventoryClient"]
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
+	if ok {
+		inventoryClient.IIventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
+	}
+	cache := newCacheSpy()
+
+	useCase := NewGetProductsByTypeUseCase(inventoryClient, cache)
+	return getProductsByTypeUsecaseToTest{useCase, inventoryClient, cache}
+}
+
+type updateProductUsecaseToTest struct {
+	useCase         usecases.IUpdateProductUseCase
+	inventoryClient *countingInventoryClientSpy
+	cache           *cacheSpy
+}
+
+func newUpdateProductUsecaseToTest(configs map[string]mockConfigure) updateProductUsecaseToTest {
+	inventoryClientConfig, ok := configs["inventoryClient"]
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
+	if ok {
+		inventoryClient.IIventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
+	}
+	cache := newCacheSpy()
+
+	useCase := NewUpdateProductUseCase(inventoryClient, cache)
+	return updateProductUsecaseToTest{useCase, inventoryClient, cache}
+}
+
 type createProductUsecaseToTest struct {
-	useCase usecases.ICreateProductUseCase
+	useCase         usecases.ICreateProductUseCase
+	inventoryClient *countingInventoryClientSpy
+	cache           *cacheSpy
 }
 
 func newCreateProductUsecaseToTest(configs map[string]mockConfigure) createProductUsecaseToTest {
 	inventoryClientConfig, ok := configs["inventoryClient"]
-	var inventoryClient interfaces.IIventoryClient
+	inventoryClient := &countingInventoryClientSpy{IIventoryClient: inventoryClientSpy{}}
 	if ok {
-		inventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
-	} else {
-		inventoryClient = inventoryClientSpy{}
+		inventoryClient.IIventoryClient = inventoryClientSpy{config: &inventoryClientConfig}
+	}
+	cache := newCacheSpy()
+
+	useCase := NewCreateProductUseCase(inventoryClient, cache)
+	return createProductUsecaseToTest{useCase, inventoryClient, cache}
+}
+
+// countingInventoryClientSpy counts the product reads reaching the inventory
+// client, each held back by delay, and those whose context was done by then.
+type countingInventoryClientSpy struct {
+	interfaces.IIventoryClient
+	delay    time.Duration
+	calls    int32
+	canceled int32
+}
+
+func (pst *countingInventoryClientSpy) Calls() int {
+	return int(atomic.LoadInt32(&pst.calls))
+}
+
+func (pst *countingInventoryClientSpy) Canceled() int {
+	return int(atomic.LoadInt32(&pst.canceled))
+}
+
+func (pst *countingInventoryClientSpy) wait(ctx context.Context) {
+	atomic.AddInt32(&pst.calls, 1)
+	time.Sleep(pst.delay)
+	if ctx.Err() != nil {
+		atomic.AddInt32(&pst.canceled, 1)
 	}
+}
+
+func (pst *countingInventoryClientSpy) GetProductById(ctx context.Context, id string) (dtos.ProductDto, error) {
+	pst.wait(ctx)
+	return pst.IIventoryClient.GetProductById(ctx, id)
+}
+
+func (pst *countingInventoryClientSpy) GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	pst.wait(ctx)
+	return pst.IIventoryClient.GetProducts(ctx, limit, offset)
+}
+
+func (pst *countingInventoryClientSpy) GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	pst.wait(ctx)
+	return pst.IIventoryClient.GetProductsByType(ctx, productType)
+}
+
+type cacheSpy struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	deleted []string
+}
+
+func newCacheSpy() *cacheSpy {
+	return &cacheSpy{entries: map[string][]byte{}}
+}
+
+func (pst *cacheSpy) Get(ctx context.Context, key string) ([]byte, bool) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	value, ok := pst.entries[key]
+	return value, ok
+}
+
+func (pst *cacheSpy) Set(ctx context.Context, key string, value []byte) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	pst.entries[key] = value
+}
+
+func (pst *cacheSpy) Delete(ctx context.Context, key string) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
 
-	useCase := NewCreateProductUseCase(inventoryClient)
-	return createProductUsecaseToTest{useCase}
+	delete(pst.entries, key)
+	pst.deleted = append(pst.deleted, key)
 }
 
 type createPurchaseUsecaseTest struct {
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestPasswordResetUC_Should_Publish_Event_For_Known_Email(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1, Email: "some@email.com"},
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), "some@email.com")
+
+	assert.NoError(t, err)
+}
+
+func Test_RequestPasswordResetUC_Should_Succeed_Silently_For_Unknown_Email(t *testing.T) {
+	config := map[string]mockConfigure{
+		"messageBroker": {
+			method:      "Publisher",
+			customError: errors.New("must not publish"),
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), "unknown@email.com")
+
+	assert.NoError(t, err)
+}
+
+func Test_RequestPasswordResetUC_Should_Return_InternalError_If_Publish_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1},
+		},
+		"messageBroker": {
+			method:      "Publisher",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), "some@email.com")
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_RequestPasswordResetUC_Should_Return_InternalError_If_Token_Could_Not_Be_Stored(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1},
+		},
+		"passwordResetRepository": {
+			method:      "Create",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.request.Perform(context.Background(), "some@email.com")
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_ConfirmPasswordResetUC_Should_Update_Password_Correctly(t *testing.T) {
+	sut := newPasswordResetUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.confirm.Perform(context.Background(), dtos.ConfirmPasswordResetDto{Token: "token", Password: "new password"})
+
+	assert.NoError(t, err)
+}
+
+func Test_ConfirmPasswordResetUC_Should_Return_BadRequestError_If_Token_Is_Invalid(t *testing.T) {
+	config := map[string]mockConfigure{
+		"passwordResetRepository": {
+			method:       "Consume",
+			customResult: nil,
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.confirm.Perform(context.Background(), dtos.ConfirmPasswordResetDto{Token: "token", Password: "new password"})
+
+	assert.IsType(t, err, internalError.BadRequestError{})
+}
+
+func Test_ConfirmPasswordResetUC_Should_Return_InternalError_If_Password_Could_Not_Be_Updated(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:      "UpdatePassword",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.confirm.Perform(context.Background(), dtos.ConfirmPasswordResetDto{Token: "token", Password: "new password"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_ConfirmPasswordResetUC_Should_Return_InternalError_If_Sessions_Could_Not_Be_Revoked(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:      "RevokeAllByUserId",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newPasswordResetUsecaseToTest(config)
+
+	err := sut.confirm.Perform(context.Background(), dtos.ConfirmPasswordResetDto{Token: "token", Password: "new password"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
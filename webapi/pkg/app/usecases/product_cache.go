@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// productListVersionKey holds the generation every cached list belongs to.
+const productListVersionKey = "products:version"
+
+const productLoadTimeout = time.Second * 10
+
+// productCache shares one inventory call between concurrent misses on a key.
+type productCache struct {
+	cache interfaces.ICache
+	group *singleflight.Group
+}
+
+// Errors are never cached.
+func (pst productCache) readThrough(ctx context.Context, key string, result interface{}, load func(ctx context.Context) (interface{}, error)) error {
+	if pst.cache != nil {
+		if cached, ok := pst.cache.Get(ctx, key); ok && json.Unmarshal(cached, result) == nil {
+			return nil
+		}
+	}
+
+	// Other callers may be waiting on the load.
+	loadCtx := context.WithoutCancel(ctx)
+	call := pst.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(loadCtx, productLoadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if pst.cache != nil {
+			pst.cache.Set(loadCtx, key, encoded)
+		}
+		return encoded, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case loaded := <-call:
+		if loaded.Err != nil {
+			return loaded.Err
+		}
+		return json.Unmarshal(loaded.Val.([]byte), result)
+	}
+}
+
+// productKey moves to a new generation on invalidate.
+func (pst productCache) productKey(ctx context.Context, id string) string {
+	return fmt.Sprintf("product:%s:%s", pst.version(ctx, pst.productVersionKey(id)), id)
+}
+
+func (pst productCache) productVersionKey(id string) string {
+	return "product:" + id + ":version"
+}
+
+func (pst productCache) listKey(ctx context.Context, suffix string) string {
+	return fmt.Sprintf("products:%s:%s", pst.version(ctx, productListVersionKey), suffix)
+}
+
+func (pst productCache) version(ctx context.Context, key string) []byte {
+	if pst.cache == nil {
+		return nil
+	}
+
+	version, ok := pst.cache.Get(ctx, key)
+	if !ok {
+		version = []byte(strconv.FormatInt(time.Now().UnixNano(), 36))
+		pst.cache.Set(ctx, key, version)
+	}
+
+	return version
+}
+
+func (pst productCache) invalidate(ctx context.Context, id string) {
+	if pst.cache == nil {
+		return
+	}
+
+	if id != "" {
+		pst.cache.Delete(ctx, pst.productVersionKey(id))
+	}
+	pst.cache.Delete(ctx, productListVersionKey)
+}
+
+func newProductCache(cache interfaces.ICache) productCache {
+	return productCache{cache, &singleflight.Group{}}
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/domain/usecases"
+
+	"go.uber.org/zap"
+)
+
+type refreshSessionUseCase struct {
+	userRepository    interfaces.IUserRepository
+	sessionRepository interfaces.ISessionRepository
+	transaction       interfaces.ITransactionManager
+	tokenManager      interfaces.ITokenManager
+	issuer            sessionIssuer
+	logger            interfaces.ILogger
+}
+
+func (pst refreshSessionUseCase) Perform(ctx context.Context, refreshToken string) (dtos.SessionDto, error) {
+	session, err := pst.sessionRepository.FindByTokenHash(ctx, pst.tokenManager.HashOpaqueToken(refreshToken))
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if session == nil {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid refresh token")
+	}
+
+	if session.RotatedAt != nil || session.RevokedAt != nil {
+		return dtos.SessionDto{}, pst.revokeFamily(ctx, session)
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Refresh token expired")
+	}
+
+	user, err := pst.userRepository.FindById(ctx, session.UserId)
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+	if user == nil {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("User no longer existe")
+	}
+
+	// Rotating the old token and issuing the new one commit together, so a
+	// failed issue never burns the token the client still holds.
+	var result dtos.SessionDto
+	var rotated bool
+	err = pst.transaction.WithinTransaction(ctx, func(ctx context.Context) error {
+		rotated, err = pst.sessionRepository.MarkAsRotated(ctx, session.Id)
+		if err != nil || !rotated {
+			return err
+		}
+
+		result, err = pst.issuer.Issue(ctx, session.UserId, session.FamilyId)
+		return err
+	})
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+	if !rotated {
+		// another request rotated this token first, so it was presented twice
+		return dtos.SessionDto{}, pst.revokeFamily(ctx, session)
+	}
+
+	return result, nil
+}
+
+func (pst refreshSessionUseCase) revokeFamily(ctx context.Context, session *entities.Session) error {
+	pst.logger.Warn(
+		"[RefreshSessionUseCase::Perform] refresh token reuse detected, revoking token family",
+		zap.Int("userId", session.UserId),
+		zap.Int("sessionId", session.Id),
+	)
+
+	if err := pst.sessionRepository.RevokeFamily(ctx, session.FamilyId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return errors.NewUnauthorizeError("Invalid refresh token")
+}
+
+func NewRefreshSessionUseCase(
+	userRepository interfaces.IUserRepository,
+	sessionRepository interfaces.ISessionRepository,
+	roleRepository interfaces.IRoleRepository,
+	transaction interfaces.ITransactionManager,
+	tokenManager interfaces.ITokenManager,
+	logger interfaces.ILogger,
+) usecases.IRefreshSessionUseCase {
+	return refreshSessionUseCase{
+		userRepository,
+		sessionRepository,
+		transaction,
+		tokenManager,
+		newSessionIssuer(tokenManager, sessionRepository, roleRepository),
+		logger,
+	}
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RefreshSessionUC_Should_Rotate_Refresh_Token_Correctly(t *testing.T) {
+	sut := newRefreshSessionUsecaseToTest(map[string]mockConfigure{})
+
+	result, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.NoError(t, err)
+	assert.IsType(t, result, dtos.SessionDto{})
+	assert.Equal(t, result.RefreshToken, "opaque token")
+}
+
+func Test_RefreshSessionUC_Should_Return_Unauthorized_If_Refresh_Token_Not_Found(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:       "FindByTokenHash",
+			customResult: nil,
+			customError:  nil,
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_InternalError_If_Some_Error_Occur_In_Repository(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:       "FindByTokenHash",
+			customResult: nil,
+			customError:  errors.New("some error"),
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_Unauthorized_If_Refresh_Token_Was_Already_Rotated(t *testing.T) {
+	rotatedAt := time.Now()
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method: "FindByTokenHash",
+			customResult: &entities.Session{
+				Id:        1,
+				UserId:    1,
+				FamilyId:  "family",
+				ExpiresAt: time.Now().Add(time.Hour),
+				RotatedAt: &rotatedAt,
+			},
+			customError: nil,
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_Unauthorized_If_Token_Was_Rotated_Concurrently(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:       "MarkAsRotated",
+			customResult: false,
+			customError:  nil,
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_Unauthorized_If_Refresh_Token_Expired(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method: "FindByTokenHash",
+			customResult: &entities.Session{
+				Id:        1,
+				UserId:    1,
+				FamilyId:  "family",
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			customError: nil,
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_Unauthorized_If_User_Not_Found(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: nil,
+			customError:  nil,
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_RefreshSessionUC_Should_Return_InternalError_If_Rotation_Could_Not_Be_Committed(t *testing.T) {
+	config := map[string]mockConfigure{
+		"transaction": {
+			method:      "WithinTransaction",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newRefreshSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "refresh token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/usecases"
+)
+
+type requestEmailVerificationUseCase struct {
+	repository interfaces.IUserRepository
+	sender     emailVerificationSender
+}
+
+func (pst requestEmailVerificationUseCase) Perform(ctx context.Context, userId int) error {
+	user, err := pst.repository.FindById(ctx, userId)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return errors.NewNotFoundError("User not found")
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return errors.NewConflictError("Email already verified")
+	}
+
+	if err := pst.sender.Send(ctx, *user); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewRequestEmailVerificationUseCase(
+	repository interfaces.IUserRepository,
+	tokenManager interfaces.ITokenManager,
+	messageBroker interfaces.IMessageBroker,
+) usecases.IRequestEmailVerificationUseCase {
+	return requestEmailVerificationUseCase{
+		repository,
+		newEmailVerificationSender(tokenManager, messageBroker),
+	}
+}
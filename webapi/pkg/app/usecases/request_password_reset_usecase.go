@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/domain/usecases"
+)
+
+const PasswordResetRequestedEvent = "password_reset_requested"
+
+const defaultPasswordResetTokenExpireIn = time.Minute * 30
+
+type requestPasswordResetUseCase struct {
+	userRepository          interfaces.IUserRepository
+	passwordResetRepository interfaces.IPasswordResetRepository
+	tokenManager            interfaces.ITokenManager
+	publisher               mailerPublisher
+}
+
+// Perform issues a single-use reset token and asks the mailer to deliver it.
+// Unknown emails succeed silently so the endpoint can't be used to find out
+// which addresses have an account.
+func (pst requestPasswordResetUseCase) Perform(ctx context.Context, email string) error {
+	user, err := pst.userRepository.FindByEmail(ctx, entities.NormalizeEmail(email))
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	if err := pst.passwordResetRepository.InvalidateByUserId(ctx, user.Id); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	token, err := pst.tokenManager.GenerateOpaqueToken()
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	expiresAt := time.Now().Add(passwordResetTokenExpireIn())
+	if err := pst.passwordResetRepository.Create(ctx, dtos.CreatePasswordResetDto{
+		UserId:    user.Id,
+		TokenHash: pst.tokenManager.HashOpaqueToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if err := pst.publisher.Publish(ctx, dtos.PasswordResetRequestedDto{
+		Event:     PasswordResetRequestedEvent,
+		UserId:    user.Id,
+		Name:      user.Name,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func passwordResetTokenExpireIn() time.Duration {
+	expireIn, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TOKEN_EXPIRE_IN"))
+	if err != nil || expireIn <= 0 {
+		return defaultPasswordResetTokenExpireIn
+	}
+
+	return expireIn
+}
+
+func NewRequestPasswordResetUseCase(
+	userRepository interfaces.IUserRepository,
+	passwordResetRepository interfaces.IPasswordResetRepository,
+	tokenManager interfaces.ITokenManager,
+	messageBroker interfaces.IMessageBroker,
+) usecases.IRequestPasswordResetUseCase {
+	return requestPasswordResetUseCase{
+		userRepository,
+		passwordResetRepository,
+		tokenManager,
+		newMailerPublisher(messageBroker),
+	}
+}
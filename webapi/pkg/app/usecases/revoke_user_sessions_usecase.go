@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/usecases"
+)
+
+type revokeUserSessionsUseCase struct {
+	revoker sessionRevoker
+}
+
+func (pst revokeUserSessionsUseCase) Perform(ctx context.Context, userId int) error {
+	if err := pst.revoker.RevokeAll(ctx, userId); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewRevokeUserSessionsUseCase(sessionRepository interfaces.ISessionRepository, denylist interfaces.ITokenDenylistRepository) usecases.IRevokeUserSessionsUseCase {
+	return revokeUserSessionsUseCase{
+		newSessionRevoker(sessionRepository, denylist),
+	}
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	internalError "webapi/pkg/app/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RevokeUserSessionsUC_Should_Revoke_All_Sessions_Correctly(t *testing.T) {
+	sut := newRevokeSessionsUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.revokeSessions.Perform(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
+
+func Test_RevokeUserSessionsUC_Should_Return_InternalError_If_Some_Error_Occur_In_Repository(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:      "FindLiveByUserId",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newRevokeSessionsUsecaseToTest(config)
+
+	err := sut.revokeSessions.Perform(context.Background(), 1)
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_RevokeUserSessionsUC_Should_Return_InternalError_If_Some_Error_Occur_In_Denylist(t *testing.T) {
+	config := map[string]mockConfigure{
+		"denylist": {
+			method:      "Add",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newRevokeSessionsUsecaseToTest(config)
+
+	err := sut.revokeSessions.Perform(context.Background(), 1)
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+)
+
+const defaultRefreshTokenExpireIn = time.Hour * 24 * 30
+
+type sessionIssuer struct {
+	tokenManager      interfaces.ITokenManager
+	sessionRepository interfaces.ISessionRepository
+	roleRepository    interfaces.IRoleRepository
+}
+
+// Issue signs a new access token for the user and persists an opaque refresh
+// token bound to the given token family. An empty familyId starts a new family.
+// The user's current roles are embedded in the access token, so role changes
+// take effect on the next sign in or refresh.
+func (pst sessionIssuer) Issue(ctx context.Context, userId int, familyId string) (dtos.SessionDto, error) {
+	roles, permissions, err := pst.authorization(ctx, userId)
+	if err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	tokenId, err := pst.tokenManager.GenerateOpaqueToken()
+	if err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	expireIn := time.Now().Add(time.Hour)
+	accessToken, err := pst.tokenManager.GenerateToken(dtos.TokenDataDto{
+		Id:          userId,
+		TokenId:     tokenId,
+		Use:         dtos.TokenUseAccess,
+		Audience:    os.Getenv("APP_ISSUER"),
+		ExpireIn:    expireIn,
+		Roles:       roles,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	if familyId == "" {
+		familyId, err = pst.tokenManager.GenerateOpaqueToken()
+		if err != nil {
+			return dtos.SessionDto{}, err
+		}
+	}
+
+	refreshToken, err := pst.tokenManager.GenerateOpaqueToken()
+	if err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	refreshExpireIn := time.Now().Add(refreshTokenExpireIn())
+	if _, err := pst.sessionRepository.Create(ctx, dtos.CreateSessionDto{
+		UserId:          userId,
+		FamilyId:        familyId,
+		TokenHash:       pst.tokenManager.HashOpaqueToken(refreshToken),
+		ExpiresAt:       refreshExpireIn,
+		AccessTokenId:   tokenId,
+		AccessExpiresAt: expireIn,
+	}); err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	return dtos.SessionDto{
+		Id:              userId,
+		TokenId:         tokenId,
+		AccessToken:     accessToken,
+		Kind:            os.Getenv("TOKEN_KIND"),
+		ExpireIn:        expireIn,
+		RefreshToken:    refreshToken,
+		RefreshExpireIn: refreshExpireIn,
+		Roles:           roles,
+		Permissions:     permissions,
+		Use:             dtos.TokenUseAccess,
+	}, nil
+}
+
+func (pst sessionIssuer) authorization(ctx context.Context, userId int) ([]string, []string, error) {
+	userRoles, err := pst.roleRepository.FindByUserId(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles := []string{}
+	granted := map[string]bool{}
+	for _, role := range userRoles {
+		roles = append(roles, role.Name)
+		for _, permission := range role.Permissions {
+			granted[permission] = true
+		}
+	}
+
+	permissions := []string{}
+	for permission := range granted {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return roles, permissions, nil
+}
+
+func refreshTokenExpireIn() time.Duration {
+	expireIn, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_EXPIRE_IN"))
+	if err != nil || expireIn <= 0 {
+		return defaultRefreshTokenExpireIn
+	}
+
+	return expireIn
+}
+
+func newSessionIssuer(
+	tokenManager interfaces.ITokenManager,
+	sessionRepository interfaces.ISessionRepository,
+	roleRepository interfaces.IRoleRepository,
+) sessionIssuer {
+	return sessionIssuer{
+		tokenManager,
+		sessionRepository,
+		roleRepository,
+	}
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/interfaces"
+)
+
+type sessionRevoker struct {
+	sessionRepository interfaces.ISessionRepository
+	denylist          interfaces.ITokenDenylistRepository
+}
+
+// RevokeAll denylists every access token the user still holds and revokes all
+// of their refresh token families, signing the user out everywhere.
+func (pst sessionRevoker) RevokeAll(ctx context.Context, userId int) error {
+	sessions, err := pst.sessionRepository.FindLiveByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := pst.denylist.Add(ctx, session.AccessTokenId, session.AccessExpiresAt); err != nil {
+			return err
+		}
+	}
+
+	return pst.sessionRepository.RevokeAllByUserId(ctx, userId)
+}
+
+func newSessionRevoker(sessionRepository interfaces.ISessionRepository, denylist interfaces.ITokenDenylistRepository) sessionRevoker {
+	return sessionRevoker{
+		sessionRepository,
+		denylist,
+	}
+}
@@ -3,58 +3,160 @@ package usecases
 import (
 	"context"
 	"os"
+	"sync"
 	"time"
 	"webapi/pkg/app/errors"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
 	"webapi/pkg/domain/usecases"
+
+	"go.uber.org/zap"
 )
 
+const defaultMfaPendingTokenExpireIn = time.Minute * 5
+
 type sessionUseCase struct {
-	repository   interfaces.IUserRepository
-	hasher       interfaces.IHasher
-	tokenManager interfaces.ITokenManager
-	logger       interfaces.ILogger
+	repository    interfaces.IUserRepository
+	mfaRepository interfaces.IMfaRepository
+	hasher        interfaces.IHasher
+	tokenManager  interfaces.ITokenManager
+	issuer        sessionIssuer
+	throttler     loginThrottler
+	decoy         *decoyPassword
+	logger        interfaces.ILogger
 }
 
 func (pst sessionUseCase) Perform(ctx context.Context, dto dtos.SignInDto) (dtos.SessionDto, error) {
 	pst.logger.Info("[SessionUseCase::Perform]")
-	user, err := pst.repository.FindByEmail(ctx, dto.Email)
+	attemptKey := accountAttemptKey(dto.Email)
+	if err := pst.throttler.Check(ctx, attemptKeys(attemptKey, dto.ClientIp)...); err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	user, err := pst.repository.FindByEmail(ctx, entities.NormalizeEmail(dto.Email))
 	if err != nil {
 		return dtos.SessionDto{}, err
 	}
 
-	if user == nil {
-		return dtos.SessionDto{}, errors.NewNotFoundError("Email not found")
+	// Unknown emails still pay for a hash comparison so response times do not
+	// tell which accounts exist.
+	hashedPassword := pst.decoy.hash(pst.hasher)
+	if user != nil {
+		hashedPassword = user.Password
+	}
+
+	if !pst.hasher.Verify(dto.Password, hashedPassword) || user == nil {
+		if err := pst.throttler.RegisterFailure(ctx, attemptKeys(attemptKey, dto.ClientIp)...); err != nil {
+			return dtos.SessionDto{}, err
+		}
+
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid credentials")
+	}
+
+	if err := pst.throttler.RegisterSuccess(ctx, attemptKey); err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	pst.rehashIfNeeded(ctx, *user, dto.Password)
+
+	mfa, err := pst.mfaRepository.FindByUserId(ctx, user.Id)
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if mfa != nil && mfa.IsEnabled() {
+		return pst.mfaChallenge(user.Id)
 	}
 
-	if !pst.hasher.Verify(dto.Password, user.Password) {
-		return dtos.SessionDto{}, errors.NewBadRequestError("Wrong password")
+	return pst.issuer.Issue(ctx, user.Id, "")
+}
+
+// rehashIfNeeded upgrades hashes written with an older algorithm or weaker
+// parameters while the plain password is at hand. A failure only delays the
+// upgrade to the next sign in.
+func (pst sessionUseCase) rehashIfNeeded(ctx context.Context, user entities.User, password string) {
+	if !pst.hasher.NeedsRehash(user.Password) {
+		return
 	}
 
-	expireIn := time.Now().Add(time.Hour * 1)
-	accessToken, err := pst.tokenManager.GenerateToken(dtos.TokenDataDto{
-		Id:       user.Id,
+	hashedPassword, err := pst.hasher.Hahser(password)
+	if err == nil {
+		err = pst.repository.UpdatePassword(ctx, user.Id, hashedPassword)
+	}
+
+	if err != nil {
+		pst.logger.Warn(
+			"[SessionUseCase::Perform] could not rehash outdated password",
+			zap.Int("userId", user.Id),
+			zap.Error(err),
+		)
+	}
+}
+
+// mfaChallenge signs a short lived token that only POST /api/v1/auth/mfa
+// accepts; the validate token use case refuses it as an access token.
+func (pst sessionUseCase) mfaChallenge(userId int) (dtos.SessionDto, error) {
+	tokenId, err := pst.tokenManager.GenerateOpaqueToken()
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+
+	expireIn := time.Now().Add(durationFromEnv("MFA_PENDING_TOKEN_EXPIRE_IN", defaultMfaPendingTokenExpireIn))
+	mfaToken, err := pst.tokenManager.GenerateToken(dtos.TokenDataDto{
+		Id:       userId,
+		TokenId:  tokenId,
+		Use:      dtos.TokenUseMfaPending,
 		Audience: os.Getenv("APP_ISSUER"),
 		ExpireIn: expireIn,
 	})
 	if err != nil {
-		return dtos.SessionDto{}, err
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
 	}
 
 	return dtos.SessionDto{
-		AccessToken: accessToken,
-		Kind:        os.Getenv("TOKEN_KIND"),
+		Id:          userId,
+		TokenId:     tokenId,
 		ExpireIn:    expireIn,
+		Use:         dtos.TokenUseMfaPending,
+		MfaRequired: true,
+		MfaToken:    mfaToken,
 	}, nil
+}
+
+// decoyPassword holds a hash of a throwaway password, computed once with the
+// configured hasher so its cost matches real password hashes.
+type decoyPassword struct {
+	once   sync.Once
+	hashed string
+}
+
+func (pst *decoyPassword) hash(hasher interfaces.IHasher) string {
+	pst.once.Do(func() {
+		pst.hashed, _ = hasher.Hahser("decoy password for unknown accounts")
+	})
 
+	return pst.hashed
 }
 
-func NewSessionUseCase(repository interfaces.IUserRepository, hasher interfaces.IHasher, tokenManager interfaces.ITokenManager, logger interfaces.ILogger) usecases.ISessionUseCase {
+func NewSessionUseCase(
+	repository interfaces.IUserRepository,
+	sessionRepository interfaces.ISessionRepository,
+	roleRepository interfaces.IRoleRepository,
+	mfaRepository interfaces.IMfaRepository,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	hasher interfaces.IHasher,
+	tokenManager interfaces.ITokenManager,
+	logger interfaces.ILogger,
+) usecases.ISessionUseCase {
 	return sessionUseCase{
 		repository,
+		mfaRepository,
 		hasher,
 		tokenManager,
+		newSessionIssuer(tokenManager, sessionRepository, roleRepository),
+		newLoginThrottler(loginAttemptStore, logger),
+		&decoyPassword{},
 		logger,
 	}
 }
@@ -3,7 +3,9 @@ package usecases
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 	internalError "webapi/pkg/app/errors"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/entities"
@@ -44,7 +46,7 @@ func Test_SessionUC_Should_Return_Error_If_Some_Error_Occur_In_Repository(t *tes
 	assert.Error(t, err)
 }
 
-func Test_SessionUC_Should_Return_NotFoundError_If_Email_Not_Found(t *testing.T) {
+func Test_SessionUC_Should_Return_Invalid_Credentials_If_Email_Not_Found(t *testing.T) {
 	config := map[string]mockConfigure{
 		"userRepository": {
 			method:       "FindById",
@@ -57,11 +59,11 @@ func Test_SessionUC_Should_Return_NotFoundError_If_Email_Not_Found(t *testing.T)
 
 	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
 
-	assert.Error(t, err)
-	assert.IsType(t, err, internalError.NotFoundError{})
+	assert.EqualError(t, err, "Invalid credentials")
+	assert.IsType(t, err, internalError.UnauthorizeError{})
 }
 
-func Test_SessionUC_Should_Return_BadRequestError_If_Password_Is_Wrong(t *testing.T) {
+func Test_SessionUC_Should_Return_Invalid_Credentials_If_Password_Is_Wrong(t *testing.T) {
 	config := map[string]mockConfigure{
 		"userRepository": {
 			method:       "FindByEmail",
@@ -79,8 +81,8 @@ func Test_SessionUC_Should_Return_BadRequestError_If_Password_Is_Wrong(t *testin
 
 	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
 
-	assert.Error(t, err)
-	assert.IsType(t, err, internalError.BadRequestError{})
+	assert.EqualError(t, err, "Invalid credentials")
+	assert.IsType(t, err, internalError.UnauthorizeError{})
 }
 
 func Test_SessionUC_Should_Return_Error_If_GenerateToken_Return_Error(t *testing.T) {
@@ -103,3 +105,224 @@ func Test_SessionUC_Should_Return_Error_If_GenerateToken_Return_Error(t *testing
 
 	assert.Error(t, err)
 }
+
+func Test_SessionUC_Should_Return_Error_If_Refresh_Session_Could_Not_Be_Persisted(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{},
+			customError:  nil,
+		},
+		"sessionRepository": {
+			method:       "Create",
+			customResult: nil,
+			customError:  errors.New("some error"),
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.Error(t, err)
+}
+
+func Test_SessionUC_Should_Carry_The_User_Roles_And_Permissions(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{},
+			customError:  nil,
+		},
+		"roleRepository": {
+			method: "FindByUserId",
+			customResult: []entities.Role{
+				{Name: entities.RoleAdmin, Permissions: []string{entities.PermissionSessionsRevoke, entities.PermissionInventoryWrite}},
+				{Name: entities.RoleCustomer, Permissions: []string{entities.PermissionInventoryWrite}},
+			},
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	result, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{entities.RoleAdmin, entities.RoleCustomer}, result.Roles)
+	assert.Equal(t, []string{entities.PermissionInventoryWrite, entities.PermissionSessionsRevoke}, result.Permissions)
+}
+
+func Test_SessionUC_Should_Return_Error_If_Roles_Could_Not_Be_Loaded(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{},
+			customError:  nil,
+		},
+		"roleRepository": {
+			method:      "FindByUserId",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.Error(t, err)
+}
+
+func Test_SessionUC_Should_Return_TooManyRequests_While_Locked_Out(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Minute)
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{},
+		},
+		"loginAttemptStore": {
+			method:       "Find",
+			customResult: &entities.LoginAttempt{Key: "account:some@email.com", Failures: 5, LockedUntil: &lockedUntil},
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{Email: "some@email.com", ClientIp: "127.0.0.1"})
+
+	assert.IsType(t, err, internalError.TooManyRequestsError{})
+	assert.True(t, err.(internalError.TooManyRequestsError).RetryAfter > 0)
+}
+
+func Test_SessionUC_Should_Allow_Sign_In_Once_Lockout_Expired(t *testing.T) {
+	lockedUntil := time.Now().Add(-time.Minute)
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{},
+		},
+		"loginAttemptStore": {
+			method:       "Find",
+			customResult: &entities.LoginAttempt{Key: "account:some@email.com", Failures: 5, LockedUntil: &lockedUntil},
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{Email: "some@email.com"})
+
+	assert.NoError(t, err)
+}
+
+func Test_SessionUC_Should_Return_Error_If_Failure_Could_Not_Be_Registered(t *testing.T) {
+	config := map[string]mockConfigure{
+		"loginAttemptStore": {
+			method:      "RegisterFailure",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{Email: "some@email.com"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_SessionUC_Should_Return_Error_If_Lockout_Could_Not_Be_Stored(t *testing.T) {
+	config := map[string]mockConfigure{
+		"hasher": {
+			method:       "Verify",
+			customResult: false,
+		},
+		"loginAttemptStore": {
+			method:      "Lock",
+			customError: errors.New("some error"),
+		},
+	}
+
+	t.Setenv("LOGIN_MAX_FAILURES", "1")
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{Email: "some@email.com"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_LoginThrottlePolicy_Should_Back_Off_Exponentially(t *testing.T) {
+	policy := loginThrottlePolicy{lockoutBase: time.Minute, lockoutMax: time.Minute * 10}
+
+	assert.Equal(t, time.Duration(0), policy.lockoutFor(4, 5))
+	assert.Equal(t, time.Minute, policy.lockoutFor(5, 5))
+	assert.Equal(t, time.Minute*2, policy.lockoutFor(6, 5))
+	assert.Equal(t, time.Minute*8, policy.lockoutFor(8, 5))
+	assert.Equal(t, time.Minute*10, policy.lockoutFor(9, 5))
+	assert.Equal(t, time.Minute*10, policy.lockoutFor(100, 5))
+}
+
+func Test_SessionUC_Should_Return_Mfa_Challenge_When_Mfa_Is_Enabled(t *testing.T) {
+	enabledAt := time.Now()
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1},
+		},
+		"mfa": {
+			customResult: &entities.UserMfa{UserId: 1, EnabledAt: &enabledAt},
+		},
+		"tokenManager": {
+			method:       "GenerateToken",
+			customResult: "mfa token",
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	result, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.NoError(t, err)
+	assert.True(t, result.MfaRequired)
+	assert.Equal(t, "mfa token", result.MfaToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+}
+
+func Test_SessionUC_Should_Sign_In_And_Rehash_Outdated_Password(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1, Password: outdatedPasswordHash},
+		},
+		"hasher": {
+			method:       "Hahser",
+			customResult: "new hash",
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reflect.ValueOf(sut.logger).Elem().FieldByName("WarnCallerCount").Interface())
+}
+
+func Test_SessionUC_Should_Sign_In_Even_If_Rehash_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 1, Password: outdatedPasswordHash},
+		},
+		"hasher": {
+			method:       "Hahser",
+			customResult: "",
+			customError:  errors.New("some error"),
+		},
+	}
+
+	sut := newSessionUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.SignInDto{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reflect.ValueOf(sut.logger).Elem().FieldByName("WarnCallerCount").Interface())
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type updateProductUseCase struct {
+	inventoryClient interfaces.IIventoryClient
+	cache           productCache
+}
+
+func (pst updateProductUseCase) Perform(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	product, err := pst.inventoryClient.UpdateProduct(ctx, dto)
+	if err != nil {
+		return product, err
+	}
+
+	pst.cache.invalidate(ctx, dto.Id)
+	return product, nil
+}
+
+func NewUpdateProductUseCase(inventoryClient interfaces.IIventoryClient, cache interfaces.ICache) usecases.IUpdateProductUseCase {
+	return updateProductUseCase{inventoryClient, newProductCache(cache)}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UpdateProductUC_Should_Execute_Correctly(t *testing.T) {
+	title := "New Title"
+	sut := newUpdateProductUsecaseToTest(map[string]mockConfigure{})
+
+	result, err := sut.useCase.Perform(context.Background(), dtos.UpdateProductDto{Id: "1", Title: &title})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.Id)
+}
+
+func Test_UpdateProductUC_Should_Return_NotFound_If_Product_Does_Not_Exist(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "UpdateProduct",
+			customResult: dtos.ProductDto{},
+			customError:  internalError.NewNotFoundError("product not found"),
+		},
+	}
+
+	sut := newUpdateProductUsecaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), dtos.UpdateProductDto{Id: "1"})
+
+	assert.IsType(t, err, internalError.NotFoundError{})
+}
+
+func Test_UpdateProductUC_Should_Invalidate_The_Product_And_Lists(t *testing.T) {
+	sut := newUpdateProductUsecaseToTest(map[string]mockConfigure{})
+
+	sut.useCase.Perform(context.Background(), dtos.UpdateProductDto{Id: "1"})
+
+	assert.Equal(t, []string{"product:1:version", productListVersionKey}, sut.cache.deleted)
+}
+
+func Test_UpdateProductUC_Should_Keep_The_Cache_If_Update_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"inventoryClient": {
+			method:       "UpdateProduct",
+			customResult: dtos.ProductDto{},
+			customError:  internalError.NewNotFoundError("product not found"),
+		},
+	}
+	sut := newUpdateProductUsecaseToTest(config)
+
+	sut.useCase.Perform(context.Background(), dtos.UpdateProductDto{Id: "1"})
+
+	assert.Empty(t, sut.cache.deleted)
+}
+
+func Test_UpdateProductUC_Should_Not_Let_A_Read_Started_Before_It_Cache_The_Old_Product(t *testing.T) {
+	sut := newUpdateProductUsecaseToTest(map[string]mockConfigure{})
+	sut.inventoryClient.delay = time.Millisecond * 50
+	getProductById := NewGetProductByIdUseCase(sut.inventoryClient, sut.cache)
+
+	read := make(chan error)
+	go func() {
+		_, err := getProductById.Perform(context.Background(), "1")
+		read <- err
+	}()
+	time.Sleep(time.Millisecond * 10)
+	sut.useCase.Perform(context.Background(), dtos.UpdateProductDto{Id: "1"})
+	assert.NoError(t, <-read)
+
+	getProductById.Perform(context.Background(), "1")
+
+	assert.Equal(t, 2, sut.inventoryClient.Calls())
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/domain/usecases"
+
+	"go.uber.org/zap"
+)
+
+type updateUserProfileUseCase struct {
+	repository   interfaces.IUserRepository
+	verification emailVerificationSender
+	logger       interfaces.ILogger
+}
+
+// Perform stores the new profile. Changing the email address marks the account
+// as unverified again and sends a verification link to the new address.
+func (pst updateUserProfileUseCase) Perform(ctx context.Context, userId int, dto dtos.UpdateUserDto) (dtos.UserProfileDto, error) {
+	dto.Email = entities.NormalizeEmail(dto.Email)
+
+	current, err := pst.repository.FindById(ctx, userId)
+	if err != nil {
+		return dtos.UserProfileDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if current == nil {
+		return dtos.UserProfileDto{}, errors.NewNotFoundError("User not found")
+	}
+
+	emailChanged := entities.NormalizeEmail(current.Email) != dto.Email
+	if emailChanged {
+		owner, err := pst.repository.FindByEmail(ctx, dto.Email)
+		if err != nil {
+			return dtos.UserProfileDto{}, errors.NewInternalError(err.Error())
+		}
+
+		if owner != nil && owner.Id != userId {
+			return dtos.UserProfileDto{}, errors.NewConflictError("Email already registered")
+		}
+	}
+
+	user, err := pst.repository.Update(ctx, userId, dto)
+	if _, conflict := err.(errors.ConflictError); conflict {
+		return dtos.UserProfileDto{}, err
+	}
+	if err != nil {
+		return dtos.UserProfileDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return dtos.UserProfileDto{}, errors.NewNotFoundError("User not found")
+	}
+
+	if emailChanged {
+		if err := pst.verification.Send(ctx, *user); err != nil {
+			pst.logger.Warn(
+				"[UpdateUserProfileUseCase::Perform] could not send email verification",
+				zap.Int("userId", user.Id),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return toUserProfileDto(*user), nil
+}
+
+func NewUpdateUserProfileUseCase(
+	repository interfaces.IUserRepository,
+	tokenManager interfaces.ITokenManager,
+	messageBroker interfaces.IMessageBroker,
+	logger interfaces.ILogger,
+) usecases.IUpdateUserProfileUseCase {
+	return updateUserProfileUseCase{
+		repository,
+		newEmailVerificationSender(tokenManager, messageBroker),
+		logger,
+	}
+}
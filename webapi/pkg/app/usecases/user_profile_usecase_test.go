@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	internalError "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetUserProfileUC_Should_Return_Profile(t *testing.T) {
+	verifiedAt := time.Now()
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: &entities.User{Id: 1, Name: "Name", Email: "some@email.com", EmailVerifiedAt: &verifiedAt},
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	result, err := sut.get.Perform(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Id)
+	assert.Equal(t, "some@email.com", result.Email)
+	assert.True(t, result.EmailVerified)
+}
+
+func Test_GetUserProfileUC_Should_Return_NotFound_If_User_Does_Not_Exist(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: nil,
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	_, err := sut.get.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.NotFoundError{})
+}
+
+func Test_UpdateUserProfileUC_Should_Update_Profile(t *testing.T) {
+	sut := newUserProfileUsecaseToTest(map[string]mockConfigure{})
+
+	result, err := sut.update.Perform(context.Background(), 1, dtos.UpdateUserDto{Name: "New Name", Email: "new@email.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "New Name", result.Name)
+	assert.Equal(t, "new@email.com", result.Email)
+	assert.False(t, result.EmailVerified)
+}
+
+func Test_UpdateUserProfileUC_Should_Return_Conflict_If_Email_Belongs_To_Another_User(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindByEmail",
+			customResult: &entities.User{Id: 2, Email: "taken@email.com"},
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	_, err := sut.update.Perform(context.Background(), 1, dtos.UpdateUserDto{Name: "Name", Email: "taken@email.com"})
+
+	assert.IsType(t, err, internalError.ConflictError{})
+}
+
+func Test_UpdateUserProfileUC_Should_Return_InternalError_If_Update_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:      "Update",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	_, err := sut.update.Perform(context.Background(), 1, dtos.UpdateUserDto{Name: "Name", Email: "new@email.com"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_UpdateUserProfileUC_Should_Succeed_Even_If_Verification_Email_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"messageBroker": {
+			method:      "Publisher",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	_, err := sut.update.Perform(context.Background(), 1, dtos.UpdateUserDto{Name: "Name", Email: "new@email.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reflect.ValueOf(sut.logger).Elem().FieldByName("WarnCallerCount").Interface())
+}
+
+func Test_ChangePasswordUC_Should_Change_Password(t *testing.T) {
+	sut := newUserProfileUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.changePassword.Perform(context.Background(), dtos.ChangePasswordDto{UserId: 1, CurrentPassword: "current", NewPassword: "new password"})
+
+	assert.NoError(t, err)
+}
+
+func Test_ChangePasswordUC_Should_Return_Unauthorized_If_Current_Password_Is_Wrong(t *testing.T) {
+	config := map[string]mockConfigure{
+		"hasher": {
+			method:       "Verify",
+			customResult: false,
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	err := sut.changePassword.Perform(context.Background(), dtos.ChangePasswordDto{UserId: 1, CurrentPassword: "wrong", NewPassword: "new password"})
+
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_ChangePasswordUC_Should_Return_TooManyRequests_While_Account_Is_Locked(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Minute)
+	config := map[string]mockConfigure{
+		"loginAttemptStore": {
+			method:       "Find",
+			customResult: &entities.LoginAttempt{LockedUntil: &lockedUntil},
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	err := sut.changePassword.Perform(context.Background(), dtos.ChangePasswordDto{UserId: 1, CurrentPassword: "current", NewPassword: "new password"})
+
+	assert.IsType(t, err, internalError.TooManyRequestsError{})
+}
+
+func Test_ChangePasswordUC_Should_Return_InternalError_If_Sessions_Cannot_Be_Revoked(t *testing.T) {
+	config := map[string]mockConfigure{
+		"sessionRepository": {
+			method:      "RevokeAllByUserId",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	err := sut.changePassword.Perform(context.Background(), dtos.ChangePasswordDto{UserId: 1, CurrentPassword: "current", NewPassword: "new password"})
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_DeleteUserUC_Should_Soft_Delete_User(t *testing.T) {
+	sut := newUserProfileUsecaseToTest(map[string]mockConfigure{})
+
+	err := sut.delete.Perform(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
+
+func Test_DeleteUserUC_Should_Return_InternalError_If_Repository_Fails(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:      "SoftDelete",
+			customError: errors.New("some error"),
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	err := sut.delete.Perform(context.Background(), 1)
+
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_UpdateUserProfileUC_Should_Return_Conflict_If_Concurrent_Update_Took_The_Email(t *testing.T) {
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:      "Update",
+			customError: internalError.NewConflictError("Email already registered"),
+		},
+	}
+
+	sut := newUserProfileUsecaseToTest(config)
+
+	_, err := sut.update.Perform(context.Background(), 1, dtos.UpdateUserDto{Name: "Name", Email: "new@email.com"})
+
+	assert.IsType(t, err, internalError.ConflictError{})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/entities"
 
@@ -75,3 +76,69 @@ func Test_ValidateTokenUC_Should_Return_Unauthorized_If_User_Not_Found(t *testin
 	assert.Error(t, err)
 	assert.IsType(t, err, internalError.UnauthorizeError{})
 }
+
+func Test_ValidateTokenUC_Should_Return_Unauthorized_If_Token_Was_Revoked(t *testing.T) {
+	config := map[string]mockConfigure{
+		"denylist": {
+			method:       "Contains",
+			customResult: true,
+			customError:  nil,
+		},
+	}
+
+	sut := newValidationTokenUseCaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "some token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_ValidateTokenUC_Should_Return_InternalError_If_Some_Error_Occur_In_Denylist(t *testing.T) {
+	config := map[string]mockConfigure{
+		"denylist": {
+			method:       "Contains",
+			customResult: false,
+			customError:  errors.New("some error"),
+		},
+	}
+
+	sut := newValidationTokenUseCaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "some token")
+
+	assert.Error(t, err)
+	assert.IsType(t, err, internalError.InternalError{})
+}
+
+func Test_ValidateTokenUC_Should_Return_Unauthorized_If_Token_Is_Not_An_Access_Token(t *testing.T) {
+	config := map[string]mockConfigure{
+		"tokenManager": {
+			method:       "VerifyToken",
+			customResult: &dtos.SessionDto{Id: 1, Use: dtos.TokenUseEmailVerification},
+		},
+	}
+
+	sut := newValidationTokenUseCaseToTest(config)
+
+	_, err := sut.useCase.Perform(context.Background(), "some token")
+
+	assert.IsType(t, err, internalError.UnauthorizeError{})
+}
+
+func Test_ValidateTokenUC_Should_Flag_Verified_Emails(t *testing.T) {
+	verifiedAt := time.Now()
+	config := map[string]mockConfigure{
+		"userRepository": {
+			method:       "FindById",
+			customResult: &entities.User{Id: 1, EmailVerifiedAt: &verifiedAt},
+		},
+	}
+
+	sut := newValidationTokenUseCaseToTest(config)
+
+	result, err := sut.useCase.Perform(context.Background(), "some token")
+
+	assert.NoError(t, err)
+	assert.True(t, result.EmailVerified)
+}
@@ -10,6 +10,7 @@ import (
 
 type validatinTokenUseCase struct {
 	repository   interfaces.IUserRepository
+	denylist     interfaces.ITokenDenylistRepository
 	tokenManager interfaces.ITokenManager
 }
 
@@ -19,6 +20,20 @@ func (pst validatinTokenUseCase) Perform(ctx context.Context, accessToken string
 		return dtos.SessionDto{}, errors.NewInternalError("Some error occur whiling validate the access token")
 	}
 
+	// Tokens issued before token uses were introduced carry none and are
+	// access tokens.
+	if authenticatedUser.Use != "" && authenticatedUser.Use != dtos.TokenUseAccess {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid token")
+	}
+
+	revoked, err := pst.denylist.Contains(ctx, authenticatedUser.TokenId, authenticatedUser.ExpireIn)
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError("Some error occur whiling validate the access token")
+	}
+	if revoked {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Token has been revoked")
+	}
+
 	user, err := pst.repository.FindById(ctx, authenticatedUser.Id)
 	if err != nil {
 		return dtos.SessionDto{}, errors.NewInternalError("Some error occur whiling validate the access token")
@@ -27,12 +42,19 @@ func (pst validatinTokenUseCase) Perform(ctx context.Context, accessToken string
 		return dtos.SessionDto{}, errors.NewUnauthorizeError("User no longer existe")
 	}
 
+	authenticatedUser.EmailVerified = user.EmailVerifiedAt != nil
+
 	return *authenticatedUser, nil
 }
 
-func NewValidatinTokenUseCase(repository interfaces.IUserRepository, tokenManager interfaces.ITokenManager) usecases.IValidationTokenUseCase {
+func NewValidatinTokenUseCase(
+	repository interfaces.IUserRepository,
+	denylist interfaces.ITokenDenylistRepository,
+	tokenManager interfaces.ITokenManager,
+) usecases.IValidationTokenUseCase {
 	return validatinTokenUseCase{
 		repository,
+		denylist,
 		tokenManager,
 	}
 }
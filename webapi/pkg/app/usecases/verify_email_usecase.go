@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/domain/usecases"
+)
+
+type verifyEmailUseCase struct {
+	repository   interfaces.IUserRepository
+	tokenManager interfaces.ITokenManager
+	denylist     interfaces.ITokenDenylistRepository
+}
+
+// Perform verifies the email the token was issued for, once. A link sent
+// before the user changed their email no longer matches.
+func (pst verifyEmailUseCase) Perform(ctx context.Context, token string) error {
+	claims, err := pst.tokenManager.VerifyToken(token)
+	if err != nil || claims.Use != dtos.TokenUseEmailVerification {
+		return errors.NewBadRequestError("Invalid or expired verification token")
+	}
+
+	used, err := pst.denylist.Contains(ctx, claims.TokenId, claims.ExpireIn)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if used {
+		return errors.NewBadRequestError("Invalid or expired verification token")
+	}
+
+	user, err := pst.repository.FindById(ctx, claims.Id)
+	if err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	if user == nil {
+		return errors.NewNotFoundError("User not found")
+	}
+
+	if claims.Email == "" || claims.Email != entities.NormalizeEmail(user.Email) {
+		return errors.NewBadRequestError("Invalid or expired verification token")
+	}
+
+	if user.EmailVerifiedAt == nil {
+		if err := pst.repository.MarkEmailAsVerified(ctx, user.Id); err != nil {
+			return errors.NewInternalError(err.Error())
+		}
+	}
+
+	if err := pst.denylist.Add(ctx, claims.TokenId, claims.ExpireIn); err != nil {
+		return errors.NewInternalError(err.Error())
+	}
+
+	return nil
+}
+
+func NewVerifyEmailUseCase(repository interfaces.IUserRepository, tokenManager interfaces.ITokenManager, denylist interfaces.ITokenDenylistRepository) usecases.IVerifyEmailUseCase {
+	return verifyEmailUseCase{
+		repository,
+		tokenManager,
+		denylist,
+	}
+}
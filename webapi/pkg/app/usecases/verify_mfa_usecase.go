@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+)
+
+type verifyMfaUseCase struct {
+	mfaRepository interfaces.IMfaRepository
+	tokenManager  interfaces.ITokenManager
+	verifier      mfaVerifier
+	issuer        sessionIssuer
+}
+
+// Perform exchanges the mfa pending token handed out at sign in, plus a TOTP
+// or recovery code, for a real session.
+func (pst verifyMfaUseCase) Perform(ctx context.Context, dto dtos.MfaChallengeDto) (dtos.SessionDto, error) {
+	claims, err := pst.tokenManager.VerifyToken(dto.MfaToken)
+	if err != nil || claims.Use != dtos.TokenUseMfaPending {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid or expired mfa token")
+	}
+
+	mfa, err := pst.mfaRepository.FindByUserId(ctx, claims.Id)
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+
+	if mfa == nil || !mfa.IsEnabled() {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid or expired mfa token")
+	}
+
+	ok, err := pst.verifier.Verify(ctx, *mfa, dto.Code, dto.ClientIp, true)
+	if err != nil {
+		return dtos.SessionDto{}, err
+	}
+
+	if !ok {
+		return dtos.SessionDto{}, errors.NewUnauthorizeError("Invalid two-factor code")
+	}
+
+	result, err := pst.issuer.Issue(ctx, claims.Id, "")
+	if err != nil {
+		return dtos.SessionDto{}, errors.NewInternalError(err.Error())
+	}
+
+	return result, nil
+}
+
+func NewVerifyMfaUseCase(
+	mfaRepository interfaces.IMfaRepository,
+	sessionRepository interfaces.ISessionRepository,
+	roleRepository interfaces.IRoleRepository,
+	loginAttemptStore interfaces.ILoginAttemptStore,
+	totp interfaces.ITotp,
+	tokenManager interfaces.ITokenManager,
+	logger interfaces.ILogger,
+) usecases.IVerifyMfaUseCase {
+	return verifyMfaUseCase{
+		mfaRepository,
+		tokenManager,
+		newMfaVerifier(mfaRepository, totp, tokenManager, loginAttemptStore, logger),
+		newSessionIssuer(tokenManager, sessionRepository, roleRepository),
+	}
+}
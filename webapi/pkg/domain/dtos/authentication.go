@@ -5,17 +5,74 @@ import "time"
 type SignInDto struct {
 	Email    string
 	Password string
+	ClientIp string
 }
 
+// Token uses keep JWTs minted for one purpose from being replayed for another.
+const (
+	TokenUseAccess            = "access"
+	TokenUseEmailVerification = "email_verification"
+	TokenUseMfaPending        = "mfa_pending"
+)
+
 type TokenDataDto struct {
-	Id       int
-	ExpireIn time.Time
-	Audience string
+	Id          int
+	TokenId     string
+	Use         string
+	Email       string
+	ExpireIn    time.Time
+	Audience    string
+	Roles       []string
+	Permissions []string
 }
 
 type SessionDto struct {
-	Id          int
-	AccessToken string
-	Kind        string
-	ExpireIn    time.Time
+	Id              int
+	TokenId         string
+	AccessToken     string
+	Kind            string
+	ExpireIn        time.Time
+	RefreshToken    string
+	RefreshExpireIn time.Time
+	Roles           []string
+	Permissions     []string
+	Use             string
+	Email           string
+	EmailVerified   bool
+	MfaRequired     bool
+	MfaToken        string
+}
+
+// HasPermissions reports whether the session was granted every permission.
+func (pst SessionDto) HasPermissions(permissions ...string) bool {
+	granted := make(map[string]bool, len(pst.Permissions))
+	for _, permission := range pst.Permissions {
+		granted[permission] = true
+	}
+
+	for _, permission := range permissions {
+		if !granted[permission] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type CreateSessionDto struct {
+	UserId          int
+	FamilyId        string
+	TokenHash       string
+	ExpiresAt       time.Time
+	AccessTokenId   string
+	AccessExpiresAt time.Time
+}
+
+type JsonWebKeyDto struct {
+	Kid string
+	Kty string
+	Alg string
+	Use string
+	N   string
+	E   string
 }
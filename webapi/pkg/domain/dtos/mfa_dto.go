@@ -0,0 +1,18 @@
+package dtos
+
+type MfaEnrollmentDto struct {
+	Secret          string
+	ProvisioningUri string
+}
+
+type MfaCodeDto struct {
+	UserId   int
+	Code     string
+	ClientIp string
+}
+
+type MfaChallengeDto struct {
+	MfaToken string
+	Code     string
+	ClientIp string
+}
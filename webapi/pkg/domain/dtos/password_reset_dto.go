@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+type CreatePasswordResetDto struct {
+	UserId    int
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type ConfirmPasswordResetDto struct {
+	Token    string
+	Password string
+}
+
+type PasswordResetRequestedDto struct {
+	Event     string    `json:"event"`
+	UserId    int       `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
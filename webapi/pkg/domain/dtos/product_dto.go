@@ -13,3 +13,21 @@ type ProductDto struct {
 	CreatedAt       string
 	UpdatedAt       string
 }
+
+// UpdateProductDto changes a product partially, nil fields keep their value.
+type UpdateProductDto struct {
+	Id            string
+	Tag           *string
+	Title         *string
+	Subtitle      *string
+	AmountInStock *int
+}
+
+// ProductsPageDto is one page of the catalog. Total counts every product, so
+// callers can tell whether more pages follow.
+type ProductsPageDto struct {
+	Products []ProductDto
+	Total    int
+	Limit    int
+	Offset   int
+}
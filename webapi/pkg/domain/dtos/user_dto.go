@@ -8,12 +8,43 @@ type CreateUserDto struct {
 	Password string
 }
 
+type UpdateUserDto struct {
+	Name  string
+	Email string
+}
+
+type ChangePasswordDto struct {
+	UserId          int
+	CurrentPassword string
+	NewPassword     string
+}
+
+type UserProfileDto struct {
+	Id            int
+	Name          string
+	Email         string
+	EmailVerified bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type CreatedUserDto struct {
-	Id          int
-	Name        string
-	Email       string
-	CreatedAt   time.Time
-	AccessToken string
-	Kind        string
-	ExpireIn    time.Time
+	Id              int
+	Name            string
+	Email           string
+	CreatedAt       time.Time
+	AccessToken     string
+	Kind            string
+	ExpireIn        time.Time
+	RefreshToken    string
+	RefreshExpireIn time.Time
+}
+
+type EmailVerificationRequestedDto struct {
+	Event     string    `json:"event"`
+	UserId    int       `json:"userId"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Link      string    `json:"link"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+type LoginAttempt struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// IsLocked reports whether the key is still serving a lockout at the given time.
+func (pst LoginAttempt) IsLocked(now time.Time) bool {
+	return pst.LockedUntil != nil && pst.LockedUntil.After(now)
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+type PasswordResetToken struct {
+	Id        int
+	UserId    int
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
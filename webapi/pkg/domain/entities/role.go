@@ -0,0 +1,17 @@
+package entities
+
+const (
+	RoleAdmin    = "admin"
+	RoleCustomer = "customer"
+)
+
+const (
+	PermissionInventoryWrite = "inventory:write"
+	PermissionSessionsRevoke = "sessions:revoke"
+)
+
+type Role struct {
+	Id          int
+	Name        string
+	Permissions []string
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type Session struct {
+	Id              int
+	UserId          int
+	FamilyId        string
+	TokenHash       string
+	ExpiresAt       time.Time
+	AccessTokenId   string
+	AccessExpiresAt time.Time
+	RotatedAt       *time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+}
@@ -1,13 +1,23 @@
 package entities
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type User struct {
-	Id        int
-	Name      string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	Id              int
+	Name            string
+	Email           string
+	Password        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
+	EmailVerifiedAt *time.Time
+}
+
+// NormalizeEmail is the form emails are stored and looked up in, so addresses
+// differing only in case or surrounding spaces map to a single account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
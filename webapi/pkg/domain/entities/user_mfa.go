@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type UserMfa struct {
+	UserId       int
+	Secret       string
+	EnabledAt    *time.Time
+	LastUsedStep *int64
+	CreatedAt    time.Time
+}
+
+// IsEnabled reports whether the enrollment was confirmed with a valid code.
+func (pst UserMfa) IsEnabled() bool {
+	return pst.EnabledAt != nil
+}
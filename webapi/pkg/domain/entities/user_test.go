@@ -0,0 +1,11 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeEmail_Should_Lower_Case_And_Trim(t *testing.T) {
+	assert.Equal(t, "some@email.com", NormalizeEmail("  Some@Email.COM "))
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IChangePasswordUseCase interface {
+	Perform(ctx context.Context, dto dtos.ChangePasswordDto) error
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IConfirmMfaUseCase interface {
+	Perform(ctx context.Context, dto dtos.MfaCodeDto) ([]string, error)
+}
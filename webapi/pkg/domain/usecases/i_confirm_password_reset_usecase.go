@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IConfirmPasswordResetUseCase interface {
+	Perform(ctx context.Context, dto dtos.ConfirmPasswordResetDto) error
+}
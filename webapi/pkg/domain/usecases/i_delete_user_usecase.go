@@ -0,0 +1,7 @@
+package usecases
+
+import "context"
+
+type IDeleteUserUseCase interface {
+	Perform(ctx context.Context, userId int) error
+}
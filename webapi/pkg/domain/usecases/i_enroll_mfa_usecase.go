@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IEnrollMfaUseCase interface {
+	Perform(ctx context.Context, userId int) (dtos.MfaEnrollmentDto, error)
+}
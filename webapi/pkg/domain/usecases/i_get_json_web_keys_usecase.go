@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IGetJsonWebKeysUseCase interface {
+	Perform(ctx context.Context) []dtos.JsonWebKeyDto
+}
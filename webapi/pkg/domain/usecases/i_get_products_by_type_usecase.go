@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IGetProductsByTypeUseCase interface {
+	Perform(ctx context.Context, productType string) ([]dtos.ProductDto, error)
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IGetProductsUseCase interface {
+	Perform(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error)
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IGetUserProfileUseCase interface {
+	Perform(ctx context.Context, userId int) (dtos.UserProfileDto, error)
+}
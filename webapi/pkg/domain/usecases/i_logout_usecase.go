@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type ILogoutUseCase interface {
+	Perform(ctx context.Context, session dtos.SessionDto) error
+}
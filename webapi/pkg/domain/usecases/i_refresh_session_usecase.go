@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IRefreshSessionUseCase interface {
+	Perform(ctx context.Context, refreshToken string) (dtos.SessionDto, error)
+}
@@ -0,0 +1,7 @@
+package usecases
+
+import "context"
+
+type IRequestPasswordResetUseCase interface {
+	Perform(ctx context.Context, email string) error
+}
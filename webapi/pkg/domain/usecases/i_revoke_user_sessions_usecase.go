@@ -0,0 +1,7 @@
+package usecases
+
+import "context"
+
+type IRevokeUserSessionsUseCase interface {
+	Perform(ctx context.Context, userId int) error
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IUpdateProductUseCase interface {
+	Perform(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error)
+}
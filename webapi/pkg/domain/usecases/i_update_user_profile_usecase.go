@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IUpdateUserProfileUseCase interface {
+	Perform(ctx context.Context, userId int, dto dtos.UpdateUserDto) (dtos.UserProfileDto, error)
+}
@@ -0,0 +1,7 @@
+package usecases
+
+import "context"
+
+type IVerifyEmailUseCase interface {
+	Perform(ctx context.Context, token string) error
+}
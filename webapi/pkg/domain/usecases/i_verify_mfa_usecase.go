@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"context"
+	"webapi/pkg/domain/dtos"
+)
+
+type IVerifyMfaUseCase interface {
+	Perform(ctx context.Context, dto dtos.MfaChallengeDto) (dtos.SessionDto, error)
+}
@@ -20,6 +20,12 @@ func HandlerAdapt(handler func(httpRequest internalHttp.HttpRequest) internalHtt
 
 		result := handler(request)
 
+		for key, values := range result.Headers {
+			for _, value := range values {
+				ginCtx.Writer.Header().Add(key, value)
+			}
+		}
+
 		ginCtx.JSON(result.StatusCode, result.Body)
 	}
 }
@@ -21,14 +21,26 @@ func GetHttpRequest(ginCtx *gin.Context) (internalHttp.HttpRequest, error) {
 		params[param.Key] = param.Value
 	}
 
+	query := make(map[string]string)
+	path := ""
+	if ginCtx.Request.URL != nil {
+		for key, values := range ginCtx.Request.URL.Query() {
+			query[key] = values[0]
+		}
+		path = ginCtx.Request.URL.Path
+	}
+
 	auth, _ := ginCtx.Get("auth")
 	tracerCtx, _ := ginCtx.Get("tracerCtx")
 
 	return internalHttp.HttpRequest{
-		Body:    body,
-		Headers: ginCtx.Request.Header,
-		Params:  params,
-		Auth:    auth,
-		Ctx:     tracerCtx.(context.Context),
+		Body:     body,
+		Headers:  ginCtx.Request.Header,
+		Params:   params,
+		Query:    query,
+		Path:     path,
+		Auth:     auth,
+		ClientIp: ginCtx.ClientIP(),
+		Ctx:      tracerCtx.(context.Context),
 	}, nil
 }
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"webapi/pkg/app/interfaces"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// broadcastCache publishes every Delete to the other instances through a Redis
+// pub/sub channel.
+type broadcastCache struct {
+	interfaces.ICache
+	logger  interfaces.ILogger
+	client  redis.UniversalClient
+	channel string
+}
+
+func (pst broadcastCache) Delete(ctx context.Context, key string) {
+	pst.ICache.Delete(ctx, key)
+
+	if err := pst.client.Publish(ctx, pst.channel, key).Err(); err != nil {
+		pst.logger.Error(fmt.Sprintf("[BroadcastCache] %s publish: %s", pst.channel, err))
+	}
+}
+
+func (pst broadcastCache) listen(subscription *redis.PubSub) {
+	for message := range subscription.Channel() {
+		pst.ICache.Delete(context.Background(), message.Payload)
+	}
+}
+
+func NewBroadcastCache(logger interfaces.ILogger, local interfaces.ICache, client redis.UniversalClient, channel string) (interfaces.ICache, error) {
+	subscription := client.Subscribe(context.Background(), channel)
+	if _, err := subscription.Receive(context.Background()); err != nil {
+		subscription.Close()
+		return nil, err
+	}
+
+	cache := broadcastCache{local, logger, client, channel}
+	go cache.listen(subscription)
+
+	return cache, nil
+}
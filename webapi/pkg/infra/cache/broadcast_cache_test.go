@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BroadcastCache_Should_Invalidate_Every_Instance(t *testing.T) {
+	_, client := newRedisToTest(t)
+	first, err := NewBroadcastCache(logger.NewLoggerSpy(), NewMemoryCache("test_broadcast_first", 10, time.Minute), client, "test_invalidations")
+	assert.NoError(t, err)
+	second, err := NewBroadcastCache(logger.NewLoggerSpy(), NewMemoryCache("test_broadcast_second", 10, time.Minute), client, "test_invalidations")
+	assert.NoError(t, err)
+	first.Set(context.Background(), "key", []byte("value"))
+	second.Set(context.Background(), "key", []byte("value"))
+
+	first.Delete(context.Background(), "key")
+
+	_, firstOk := first.Get(context.Background(), "key")
+	assert.False(t, firstOk)
+	assert.Eventually(t, func() bool {
+		_, secondOk := second.Get(context.Background(), "key")
+		return !secondOk
+	}, time.Second, time.Millisecond*10)
+}
+
+func Test_BroadcastCache_Should_Keep_Entries_Local(t *testing.T) {
+	server, client := newRedisToTest(t)
+	sut, _ := NewBroadcastCache(logger.NewLoggerSpy(), NewMemoryCache("test_broadcast_local", 10, time.Minute), client, "test_invalidations")
+
+	sut.Set(context.Background(), "key", []byte("value"))
+
+	assert.Empty(t, server.Keys())
+}
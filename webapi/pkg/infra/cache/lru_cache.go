@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"container/list"
+	"expvar"
+	"sync"
+	"time"
+)
+
+var now = time.Now
+
+// stats publishes hits, misses and evictions of every cache under /debug/vars,
+// keyed as "<cache name>.<counter>".
+var stats = expvar.NewMap("cache")
+
+type ICache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+	Delete(key string)
+}
+
+type lruEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// lruCache is a bounded in-memory cache. Entries expire after ttl and, once
+// capacity is reached, the least recently used entry makes room for new ones.
+type lruCache struct {
+	name     string
+	capacity int
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func (pst *lruCache) Get(key string) (interface{}, bool) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	element, ok := pst.entries[key]
+	if !ok {
+		pst.count("misses")
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !now().Before(entry.expiresAt) {
+		pst.remove(element)
+		pst.count("misses")
+		return nil, false
+	}
+
+	pst.order.MoveToFront(element)
+	pst.count("hits")
+	return entry.value, true
+}
+
+func (pst *lruCache) Set(key string, value interface{}) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	expiresAt := now().Add(pst.ttl)
+
+	if element, ok := pst.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		pst.order.MoveToFront(element)
+		return
+	}
+
+	pst.entries[key] = pst.order.PushFront(&lruEntry{key, value, expiresAt})
+
+	for pst.order.Len() > pst.capacity {
+		pst.remove(pst.order.Back())
+		pst.count("evictions")
+	}
+}
+
+func (pst *lruCache) Delete(key string) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	if element, ok := pst.entries[key]; ok {
+		pst.remove(element)
+	}
+}
+
+func (pst *lruCache) remove(element *list.Element) {
+	pst.order.Remove(element)
+	delete(pst.entries, element.Value.(*lruEntry).key)
+}
+
+func (pst *lruCache) count(counter string) {
+	stats.Add(pst.name+"."+counter, 1)
+}
+
+func NewLruCache(name string, capacity int, ttl time.Duration) ICache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &lruCache{
+		name:     name,
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func counter(name string) int64 {
+	value, ok := stats.Get(name).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+
+	return value.Value()
+}
+
+func Test_LruCache_Should_Return_Stored_Value(t *testing.T) {
+	sut := NewLruCache("test_get", 10, time.Minute)
+
+	sut.Set("key", "value")
+	value, ok := sut.Get("key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, int64(1), counter("test_get.hits"))
+}
+
+func Test_LruCache_Should_Count_Misses(t *testing.T) {
+	sut := NewLruCache("test_miss", 10, time.Minute)
+
+	_, ok := sut.Get("key")
+
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), counter("test_miss.misses"))
+}
+
+func Test_LruCache_Should_Expire_Entries_After_Ttl(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	sut := NewLruCache("test_ttl", 10, time.Minute)
+
+	sut.Set("key", "value")
+	current = current.Add(time.Minute)
+	_, ok := sut.Get("key")
+
+	assert.False(t, ok)
+}
+
+func Test_LruCache_Should_Evict_Least_Recently_Used(t *testing.T) {
+	sut := NewLruCache("test_evict", 2, time.Minute)
+
+	sut.Set("first", 1)
+	sut.Set("second", 2)
+	sut.Get("first")
+	sut.Set("third", 3)
+
+	_, firstOk := sut.Get("first")
+	_, secondOk := sut.Get("second")
+	_, thirdOk := sut.Get("third")
+
+	assert.True(t, firstOk)
+	assert.False(t, secondOk)
+	assert.True(t, thirdOk)
+	assert.Equal(t, int64(1), counter("test_evict.evictions"))
+}
+
+func Test_LruCache_Should_Forget_Deleted_Key(t *testing.T) {
+	sut := NewLruCache("test_delete", 10, time.Minute)
+
+	sut.Set("key", "value")
+	sut.Delete("key")
+	_, ok := sut.Get("key")
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"time"
+	"webapi/pkg/app/interfaces"
+)
+
+// memoryCache serves interfaces.ICache from an lruCache local to this
+// instance.
+type memoryCache struct {
+	lru ICache
+}
+
+func (pst memoryCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, ok := pst.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	return value.([]byte), true
+}
+
+func (pst memoryCache) Set(ctx context.Context, key string, value []byte) {
+	pst.lru.Set(key, value)
+}
+
+func (pst memoryCache) Delete(ctx context.Context, key string) {
+	pst.lru.Delete(key)
+}
+
+func NewMemoryCache(name string, capacity int, ttl time.Duration) interfaces.ICache {
+	return memoryCache{NewLruCache(name, capacity, ttl)}
+}
@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryCache_Should_Return_Stored_Value(t *testing.T) {
+	sut := NewMemoryCache("test_memory", 10, time.Minute)
+
+	sut.Set(context.Background(), "key", []byte("value"))
+	value, ok := sut.Get(context.Background(), "key")
+
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func Test_MemoryCache_Should_Forget_Deleted_Key(t *testing.T) {
+	sut := NewMemoryCache("test_memory_delete", 10, time.Minute)
+
+	sut.Set(context.Background(), "key", []byte("value"))
+	sut.Delete(context.Background(), "key")
+	_, ok := sut.Get(context.Background(), "key")
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const defaultProductCacheSize = 10000
+const defaultProductCacheTtl = time.Second * 30
+const productInvalidationChannel = "products:invalidations"
+
+var errRedisStoreWithoutClient = errors.New("PRODUCT_CACHE_STORE=redis requires REDIS_URI")
+
+// NewProductCache returns nil when PRODUCT_CACHE_TTL is zero.
+func NewProductCache(logger interfaces.ILogger, client *redis.Client) (interfaces.ICache, error) {
+	ttl := defaultProductCacheTtl
+	if value, err := time.ParseDuration(os.Getenv("PRODUCT_CACHE_TTL")); err == nil && value >= 0 {
+		ttl = value
+	}
+	if ttl == 0 {
+		return nil, nil
+	}
+
+	switch store := os.Getenv("PRODUCT_CACHE_STORE"); store {
+	case "", "memory":
+		size := defaultProductCacheSize
+		if value, err := strconv.Atoi(os.Getenv("PRODUCT_CACHE_SIZE")); err == nil && value > 0 {
+			size = value
+		}
+
+		local := NewMemoryCache("products", size, ttl)
+		if client == nil {
+			return local, nil
+		}
+
+		return NewBroadcastCache(logger, local, client, productInvalidationChannel)
+	case "redis":
+		if client == nil {
+			return nil, errRedisStoreWithoutClient
+		}
+
+		return NewRedisCache(logger, client, "products", ttl), nil
+	default:
+		return nil, fmt.Errorf("unknown product cache store %q", store)
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewProductCache_Should_Keep_Products_In_Memory_By_Default(t *testing.T) {
+	t.Setenv("PRODUCT_CACHE_STORE", "")
+
+	sut, err := NewProductCache(logger.NewLoggerSpy(), nil)
+
+	assert.NoError(t, err)
+	assert.IsType(t, memoryCache{}, sut)
+}
+
+func Test_NewProductCache_Should_Broadcast_Invalidations_With_Redis(t *testing.T) {
+	_, client := newRedisToTest(t)
+	t.Setenv("PRODUCT_CACHE_STORE", "memory")
+
+	sut, err := NewProductCache(logger.NewLoggerSpy(), client)
+
+	assert.NoError(t, err)
+	assert.IsType(t, broadcastCache{}, sut)
+}
+
+func Test_NewProductCache_Should_Share_Products_Through_Redis(t *testing.T) {
+	_, client := newRedisToTest(t)
+	t.Setenv("PRODUCT_CACHE_STORE", "redis")
+
+	sut, err := NewProductCache(logger.NewLoggerSpy(), client)
+
+	assert.NoError(t, err)
+	assert.IsType(t, redisCache{}, sut)
+}
+
+func Test_NewProductCache_Should_Require_Redis_For_The_Redis_Store(t *testing.T) {
+	t.Setenv("PRODUCT_CACHE_STORE", "redis")
+
+	_, err := NewProductCache(logger.NewLoggerSpy(), nil)
+
+	assert.Equal(t, errRedisStoreWithoutClient, err)
+}
+
+func Test_NewProductCache_Should_Refuse_Unknown_Store(t *testing.T) {
+	t.Setenv("PRODUCT_CACHE_STORE", "memcached")
+
+	_, err := NewProductCache(logger.NewLoggerSpy(), nil)
+
+	assert.EqualError(t, err, `unknown product cache store "memcached"`)
+}
+
+func Test_NewProductCache_Should_Be_Disabled_By_A_Zero_Ttl(t *testing.T) {
+	t.Setenv("PRODUCT_CACHE_TTL", "0s")
+
+	sut, err := NewProductCache(logger.NewLoggerSpy(), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, sut)
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisCache prefixes its keys with the cache name.
+type redisCache struct {
+	logger interfaces.ILogger
+	client redis.UniversalClient
+	name   string
+	ttl    time.Duration
+}
+
+func (pst redisCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, err := pst.client.Get(ctx, pst.key(key)).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			pst.logger.Warn(fmt.Sprintf("[RedisCache] %s get: %s", pst.name, err))
+		}
+		pst.count("misses")
+		return nil, false
+	}
+
+	pst.count("hits")
+	return value, true
+}
+
+func (pst redisCache) Set(ctx context.Context, key string, value []byte) {
+	if err := pst.client.Set(ctx, pst.key(key), value, pst.ttl).Err(); err != nil {
+		pst.logger.Warn(fmt.Sprintf("[RedisCache] %s set: %s", pst.name, err))
+	}
+}
+
+func (pst redisCache) Delete(ctx context.Context, key string) {
+	if err := pst.client.Del(ctx, pst.key(key)).Err(); err != nil {
+		pst.logger.Error(fmt.Sprintf("[RedisCache] %s delete: %s", pst.name, err))
+	}
+}
+
+func (pst redisCache) key(key string) string {
+	return pst.name + ":" + key
+}
+
+func (pst redisCache) count(counter string) {
+	stats.Add(pst.name+"."+counter, 1)
+}
+
+func NewRedisCache(logger interfaces.ILogger, client redis.UniversalClient, name string, ttl time.Duration) interfaces.ICache {
+	return redisCache{logger, client, name, ttl}
+}
+
+// NewRedisClient connects to REDIS_URI, such as redis://:password@host:6379/0.
+// It returns nil when REDIS_URI is not set. The caller must Close the client.
+func NewRedisClient() (*redis.Client, error) {
+	uri := os.Getenv("REDIS_URI")
+	if uri == "" {
+		return nil, nil
+	}
+
+	options, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return redis.NewClient(options), nil
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RedisCache_Should_Store_Prefixed_Keys_With_Ttl(t *testing.T) {
+	server, client := newRedisToTest(t)
+	sut := NewRedisCache(logger.NewLoggerSpy(), client, "test_redis", time.Minute)
+
+	sut.Set(context.Background(), "key", []byte("value"))
+	value, ok := sut.Get(context.Background(), "key")
+
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+	assert.True(t, server.Exists("test_redis:key"))
+	assert.Equal(t, time.Minute, server.TTL("test_redis:key"))
+	assert.Equal(t, int64(1), counter("test_redis.hits"))
+}
+
+func Test_RedisCache_Should_Forget_Deleted_Key(t *testing.T) {
+	_, client := newRedisToTest(t)
+	sut := NewRedisCache(logger.NewLoggerSpy(), client, "test_redis_delete", time.Minute)
+
+	sut.Set(context.Background(), "key", []byte("value"))
+	sut.Delete(context.Background(), "key")
+	_, ok := sut.Get(context.Background(), "key")
+
+	assert.False(t, ok)
+}
+
+func Test_RedisCache_Should_Report_A_Miss_If_The_Server_Is_Down(t *testing.T) {
+	server, client := newRedisToTest(t)
+	loggerSpy := logger.NewLoggerSpy()
+	sut := NewRedisCache(loggerSpy, client, "test_redis_down", time.Minute)
+	server.Close()
+
+	_, ok := sut.Get(context.Background(), "key")
+
+	assert.False(t, ok)
+	assert.Equal(t, 1, int(reflect.ValueOf(loggerSpy).Elem().FieldByName("WarnCallerCount").Int()))
+}
+
+func Test_NewRedisClient_Should_Return_Nil_Without_Uri(t *testing.T) {
+	t.Setenv("REDIS_URI", "")
+
+	client, err := NewRedisClient()
+
+	assert.NoError(t, err)
+	assert.Nil(t, client)
+}
+
+func Test_NewRedisClient_Should_Refuse_Malformed_Uri(t *testing.T) {
+	t.Setenv("REDIS_URI", "http://localhost")
+
+	_, err := NewRedisClient()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"os"
+	"strconv"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const defaultUserCacheSize = 10000
+const defaultUserCacheTtl = time.Second * 30
+const userInvalidationChannel = "users:invalidations"
+
+// NewUserCache returns nil when USER_CACHE_TTL is zero. Entries never leave the
+// instance, only their invalidations go through client.
+func NewUserCache(logger interfaces.ILogger, client *redis.Client) (interfaces.ICache, error) {
+	ttl := defaultUserCacheTtl
+	if value, err := time.ParseDuration(os.Getenv("USER_CACHE_TTL")); err == nil && value >= 0 {
+		ttl = value
+	}
+	if ttl == 0 {
+		return nil, nil
+	}
+
+	size := defaultUserCacheSize
+	if value, err := strconv.Atoi(os.Getenv("USER_CACHE_SIZE")); err == nil && value > 0 {
+		size = value
+	}
+
+	local := NewMemoryCache("users", size, ttl)
+	if client == nil {
+		return local, nil
+	}
+
+	return NewBroadcastCache(logger, local, client, userInvalidationChannel)
+}
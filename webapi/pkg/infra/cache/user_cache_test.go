@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"testing"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewUserCache_Should_Keep_Users_In_Memory_Without_Redis(t *testing.T) {
+	sut, err := NewUserCache(logger.NewLoggerSpy(), nil)
+
+	assert.NoError(t, err)
+	assert.IsType(t, memoryCache{}, sut)
+}
+
+func Test_NewUserCache_Should_Broadcast_Invalidations_With_Redis(t *testing.T) {
+	_, client := newRedisToTest(t)
+
+	sut, err := NewUserCache(logger.NewLoggerSpy(), client)
+
+	assert.NoError(t, err)
+	assert.IsType(t, broadcastCache{}, sut)
+}
+
+func Test_NewUserCache_Should_Be_Disabled_By_A_Zero_Ttl(t *testing.T) {
+	t.Setenv("USER_CACHE_TTL", "0s")
+
+	sut, err := NewUserCache(logger.NewLoggerSpy(), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, sut)
+}
@@ -2,11 +2,23 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/newrelic/go-agent/v3/integrations/nrpq"
+	_ "modernc.org/sqlite"
 )
 
+const SqliteDriver = "sqlite"
+
+const defaultMaxOpenConns = 20
+const defaultMaxIdleConns = 10
+const defaultConnMaxLifetime = time.Minute * 30
+const defaultConnMaxIdleTime = time.Minute * 5
+
 var openConnetion = sql.Open
 
 func GetConnection(driver, connectionString string) (*sql.DB, error) {
@@ -16,6 +28,12 @@ func GetConnection(driver, connectionString string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	if driver == SqliteDriver {
+		configureSqlitePool(db)
+	} else {
+		configurePool(db)
+	}
+
 	err = db.Ping()
 	if err != nil {
 		log.Printf("error while check database connection: %v", err)
@@ -24,3 +42,39 @@ func GetConnection(driver, connectionString string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+func configurePool(db *sql.DB) {
+	db.SetMaxOpenConns(intFromEnv("DB_MAX_OPEN_CONNS", defaultMaxOpenConns))
+	db.SetMaxIdleConns(intFromEnv("DB_MAX_IDLE_CONNS", defaultMaxIdleConns))
+	db.SetConnMaxLifetime(durationFromEnv("DB_CONN_MAX_LIFETIME", defaultConnMaxLifetime))
+	db.SetConnMaxIdleTime(durationFromEnv("DB_CONN_MAX_IDLE_TIME", defaultConnMaxIdleTime))
+}
+
+// An in-memory database only lives as long as its connection.
+func configureSqlitePool(db *sql.DB) {
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+}
+
+// Times must be bound in UTC to compare with CURRENT_TIMESTAMP.
+func SqliteConnectionString(name string) string {
+	return fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite", name)
+}
+
+func intFromEnv(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value >= 0 {
+		return value
+	}
+
+	return fallback
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value >= 0 {
+		return value
+	}
+
+	return fallback
+}
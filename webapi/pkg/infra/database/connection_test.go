@@ -29,3 +29,29 @@ func Test_Should_Return_Error_When_Try_To_Connection(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func Test_Should_Configure_Pool_From_Env(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "7")
+	openConnetion = func(driver, connectionString string) (*sql.DB, error) {
+		db, _, _ := sqlmock.New()
+		return db, nil
+	}
+
+	connection, err := GetConnection("some driver", "some connection string")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, connection.Stats().MaxOpenConnections)
+}
+
+func Test_Should_Use_Default_Pool_When_Env_Is_Invalid(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+	openConnetion = func(driver, connectionString string) (*sql.DB, error) {
+		db, _, _ := sqlmock.New()
+		return db, nil
+	}
+
+	connection, err := GetConnection("some driver", "some connection string")
+
+	assert.NoError(t, err)
+	assert.Equal(t, defaultMaxOpenConns, connection.Stats().MaxOpenConnections)
+}
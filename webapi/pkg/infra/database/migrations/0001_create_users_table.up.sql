@@ -1,4 +1,4 @@
-CREATE TABLE public.users (
+CREATE TABLE IF NOT EXISTS public.users (
   id SERIAL NOT NULL,
   "name" VARCHAR NOT NULL,
   email VARCHAR NOT NULL,
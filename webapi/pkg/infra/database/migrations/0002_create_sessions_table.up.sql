@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.sessions (
+  id SERIAL NOT NULL,
+  user_id INTEGER NOT NULL,
+  family_id VARCHAR NOT NULL,
+  token_hash VARCHAR NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  access_token_id VARCHAR NOT NULL,
+  access_expires_at TIMESTAMPTZ NOT NULL,
+  rotated_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT sessions_pkey PRIMARY KEY (id),
+  CONSTRAINT sessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS sessions_token_hash_idx ON public.sessions (token_hash);
+CREATE INDEX IF NOT EXISTS sessions_family_id_idx ON public.sessions (family_id);
+CREATE INDEX IF NOT EXISTS sessions_access_token_id_idx ON public.sessions (access_token_id);
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON public.sessions (user_id);
+ALTER TABLE public.sessions OWNER TO postgres;
+GRANT ALL ON TABLE public.sessions TO postgres;
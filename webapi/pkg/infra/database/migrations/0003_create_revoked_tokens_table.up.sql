@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS public.revoked_tokens (
+  token_id VARCHAR NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT revoked_tokens_pkey PRIMARY KEY (token_id)
+);
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON public.revoked_tokens (expires_at);
+ALTER TABLE public.revoked_tokens OWNER TO postgres;
+GRANT ALL ON TABLE public.revoked_tokens TO postgres;
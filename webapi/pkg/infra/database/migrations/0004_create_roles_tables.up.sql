@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS public.roles (
+  id SERIAL NOT NULL,
+  "name" VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT roles_pkey PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS roles_name_idx ON public.roles ("name");
+ALTER TABLE public.roles OWNER TO postgres;
+GRANT ALL ON TABLE public.roles TO postgres;
+
+CREATE TABLE IF NOT EXISTS public.role_permissions (
+  role_id INTEGER NOT NULL,
+  "permission" VARCHAR NOT NULL,
+  CONSTRAINT role_permissions_pkey PRIMARY KEY (role_id, "permission"),
+  CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles (id) ON DELETE CASCADE
+);
+ALTER TABLE public.role_permissions OWNER TO postgres;
+GRANT ALL ON TABLE public.role_permissions TO postgres;
+
+CREATE TABLE IF NOT EXISTS public.user_roles (
+  user_id INTEGER NOT NULL,
+  role_id INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id),
+  CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id),
+  CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles (id) ON DELETE CASCADE
+);
+ALTER TABLE public.user_roles OWNER TO postgres;
+GRANT ALL ON TABLE public.user_roles TO postgres;
+
+INSERT INTO public.roles ("name") VALUES ('admin'), ('customer')
+  ON CONFLICT ("name") DO NOTHING;
+INSERT INTO public.role_permissions (role_id, "permission")
+  SELECT id, p.permission
+  FROM public.roles, (VALUES ('inventory:write'), ('sessions:revoke')) AS p (permission)
+  WHERE "name" = 'admin'
+  ON CONFLICT (role_id, "permission") DO NOTHING;
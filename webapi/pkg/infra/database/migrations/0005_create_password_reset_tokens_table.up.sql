@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS public.password_reset_tokens (
+  id SERIAL NOT NULL,
+  user_id INTEGER NOT NULL,
+  token_hash VARCHAR NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT password_reset_tokens_pkey PRIMARY KEY (id),
+  CONSTRAINT password_reset_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS password_reset_tokens_token_hash_idx ON public.password_reset_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_id_idx ON public.password_reset_tokens (user_id);
+ALTER TABLE public.password_reset_tokens OWNER TO postgres;
+GRANT ALL ON TABLE public.password_reset_tokens TO postgres;
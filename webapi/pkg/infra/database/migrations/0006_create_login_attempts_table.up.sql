@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS public.login_attempts (
+  key VARCHAR(320) NOT NULL,
+  failures INTEGER NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_until TIMESTAMPTZ,
+  CONSTRAINT login_attempts_pkey PRIMARY KEY (key)
+);
+CREATE INDEX IF NOT EXISTS login_attempts_last_failure_at_idx ON public.login_attempts (last_failure_at);
+ALTER TABLE public.login_attempts OWNER TO postgres;
+GRANT ALL ON TABLE public.login_attempts TO postgres;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS public.user_mfa (
+  user_id INTEGER NOT NULL,
+  secret VARCHAR NOT NULL,
+  enabled_at TIMESTAMPTZ,
+  last_used_step BIGINT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT user_mfa_pkey PRIMARY KEY (user_id),
+  CONSTRAINT user_mfa_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+ALTER TABLE public.user_mfa OWNER TO postgres;
+GRANT ALL ON TABLE public.user_mfa TO postgres;
+
+CREATE TABLE IF NOT EXISTS public.mfa_recovery_codes (
+  id SERIAL NOT NULL,
+  user_id INTEGER NOT NULL,
+  code_hash VARCHAR NOT NULL,
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT mfa_recovery_codes_pkey PRIMARY KEY (id),
+  CONSTRAINT mfa_recovery_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.user_mfa (user_id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS mfa_recovery_codes_user_id_code_hash_idx ON public.mfa_recovery_codes (user_id, code_hash);
+ALTER TABLE public.mfa_recovery_codes OWNER TO postgres;
+GRANT ALL ON TABLE public.mfa_recovery_codes TO postgres;
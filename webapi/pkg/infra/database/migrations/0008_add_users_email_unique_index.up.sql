@@ -0,0 +1,26 @@
+-- Accounts whose emails only differ in case or surrounding spaces must be
+-- merged first, see "Duplicated emails" in the README.
+DO $$
+DECLARE
+  conflicts TEXT;
+BEGIN
+  SELECT string_agg(conflict, '; ') INTO conflicts
+  FROM (
+    SELECT lower(trim(email)) || ' (ids ' || string_agg(id::TEXT, ', ' ORDER BY id) || ')' AS conflict
+    FROM public.users
+    WHERE deleted_at IS NULL
+    GROUP BY lower(trim(email))
+    HAVING count(*) > 1
+  ) duplicated;
+
+  IF conflicts IS NOT NULL THEN
+    RAISE EXCEPTION 'users share an email once normalized, merge them before migrating: %', conflicts;
+  END IF;
+END
+$$;
+
+UPDATE public.users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+-- Soft deleted accounts do not hold on to their address, so it can be used to
+-- sign up again.
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON public.users (lower(email)) WHERE deleted_at IS NULL;
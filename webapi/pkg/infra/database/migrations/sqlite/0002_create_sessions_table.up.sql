@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS sessions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  family_id VARCHAR NOT NULL,
+  token_hash VARCHAR NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  access_token_id VARCHAR NOT NULL,
+  access_expires_at TIMESTAMP NOT NULL,
+  rotated_at TIMESTAMP,
+  revoked_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS sessions_token_hash_idx ON sessions (token_hash);
+CREATE INDEX IF NOT EXISTS sessions_family_id_idx ON sessions (family_id);
+CREATE INDEX IF NOT EXISTS sessions_access_token_id_idx ON sessions (access_token_id);
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id);
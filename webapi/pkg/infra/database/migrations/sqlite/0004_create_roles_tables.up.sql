@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS roles (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  "name" VARCHAR NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS roles_name_idx ON roles ("name");
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+  role_id INTEGER NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  "permission" VARCHAR NOT NULL,
+  PRIMARY KEY (role_id, "permission")
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+  user_id INTEGER NOT NULL REFERENCES users (id),
+  role_id INTEGER NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO roles ("name") VALUES ('admin'), ('customer')
+  ON CONFLICT ("name") DO NOTHING;
+INSERT INTO role_permissions (role_id, "permission")
+  SELECT roles.id, p.permission
+  FROM roles, (SELECT 'inventory:write' AS permission UNION ALL SELECT 'sessions:revoke') AS p
+  WHERE roles."name" = 'admin'
+  ON CONFLICT (role_id, "permission") DO NOTHING;
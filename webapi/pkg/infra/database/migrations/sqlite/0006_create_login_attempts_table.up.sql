@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+  key VARCHAR(320) NOT NULL PRIMARY KEY,
+  failures INTEGER NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_until TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS login_attempts_last_failure_at_idx ON login_attempts (last_failure_at);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS user_mfa (
+  user_id INTEGER NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+  secret VARCHAR NOT NULL,
+  enabled_at TIMESTAMP,
+  last_used_step BIGINT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL REFERENCES user_mfa (user_id) ON DELETE CASCADE,
+  code_hash VARCHAR NOT NULL,
+  used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS mfa_recovery_codes_user_id_code_hash_idx ON mfa_recovery_codes (user_id, code_hash);
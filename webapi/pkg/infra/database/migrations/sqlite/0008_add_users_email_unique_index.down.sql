@@ -0,0 +1,2 @@
+-- Emails stay normalized, there is no way to know their original case.
+DROP INDEX IF EXISTS users_email_lower_idx;
@@ -0,0 +1,25 @@
+-- Accounts whose emails only differ in case or surrounding spaces must be
+-- merged first, see "Duplicated emails" in the README. SQLite cannot put their
+-- ids in the error, the README query lists them.
+CREATE TEMP TABLE users_email_conflicts (email VARCHAR NOT NULL);
+
+CREATE TEMP TRIGGER users_email_conflicts_abort AFTER INSERT ON users_email_conflicts
+BEGIN
+  SELECT RAISE(ABORT, 'users share an email once normalized, merge them before migrating (see "Duplicated emails" in the README)');
+END;
+
+INSERT INTO users_email_conflicts (email)
+SELECT lower(trim(email))
+FROM users
+WHERE deleted_at IS NULL
+GROUP BY lower(trim(email))
+HAVING count(*) > 1;
+
+DROP TRIGGER users_email_conflicts_abort;
+DROP TABLE users_email_conflicts;
+
+UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+-- Soft deleted accounts do not hold on to their address, so it can be used to
+-- sign up again.
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON users (lower(email)) WHERE deleted_at IS NULL;
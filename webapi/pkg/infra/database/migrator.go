@@ -0,0 +1,340 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"webapi/pkg/app/interfaces"
+)
+
+//go:embed migrations/*.sql migrations/sqlite/*.sql
+var embeddedMigrations embed.FS
+
+// migrationsLockId keys the Postgres advisory lock held while migrating.
+const migrationsLockId = 7_346_152_901
+
+type migrationDialect struct {
+	dir         string
+	lock        string
+	unlock      string
+	createTable string
+}
+
+var postgresDialect = migrationDialect{
+	dir:    "migrations",
+	lock:   `SELECT pg_advisory_lock($1)`,
+	unlock: `SELECT pg_advisory_unlock($1)`,
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+						version BIGINT NOT NULL,
+						name VARCHAR NOT NULL,
+						applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+						CONSTRAINT schema_migrations_pkey PRIMARY KEY (version)
+					)`,
+}
+
+var sqliteDialect = migrationDialect{
+	dir: "migrations/sqlite",
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+						version BIGINT NOT NULL PRIMARY KEY,
+						name VARCHAR NOT NULL,
+						applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+					)`,
+}
+
+func dialectFor(driver string) migrationDialect {
+	if driver == SqliteDriver {
+		return sqliteDialect
+	}
+
+	return postgresDialect
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type IMigrator interface {
+	Up(ctx context.Context) ([]Migration, error)
+	Down(ctx context.Context, steps int) ([]Migration, error)
+	Redo(ctx context.Context) ([]Migration, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+	Pending(ctx context.Context) ([]Migration, error)
+}
+
+type migrator struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	dialect      migrationDialect
+	migrations   []Migration
+}
+
+// Up applies every pending migration, each one in its own transaction.
+func (pst migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := pst.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := pst.pending(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			if err := pst.run(ctx, conn, migration, migration.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name); err != nil {
+				return err
+			}
+
+			pst.logger.Info(fmt.Sprintf("[Migrator::Up] applied %04d_%s", migration.Version, migration.Name))
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (pst migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := pst.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := pst.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(pst.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := pst.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			if err := pst.run(ctx, conn, migration, migration.Down, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+				return err
+			}
+
+			pst.logger.Info(fmt.Sprintf("[Migrator::Down] reverted %04d_%s", migration.Version, migration.Name))
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Redo reverts the last applied migration and applies it again.
+func (pst migrator) Redo(ctx context.Context) ([]Migration, error) {
+	reverted, err := pst.Down(ctx, 1)
+	if err != nil || len(reverted) == 0 {
+		return reverted, err
+	}
+
+	return pst.Up(ctx)
+}
+
+func (pst migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := pst.dbConnection.Conn(ctx)
+	if err != nil {
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := pst.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(pst.migrations))
+	for _, migration := range pst.migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (pst migrator) Pending(ctx context.Context) ([]Migration, error) {
+	conn, err := pst.dbConnection.Conn(ctx)
+	if err != nil {
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	defer conn.Close()
+
+	return pst.pending(ctx, conn)
+}
+
+func (pst migrator) pending(ctx context.Context, conn *sql.Conn) ([]Migration, error) {
+	applied, err := pst.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+	for _, migration := range pst.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+func (pst migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, pst.dialect.createTable); err != nil {
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			pst.logger.Error(err.Error())
+			return nil, err
+		}
+
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+func (pst migrator) run(ctx context.Context, conn *sql.Conn, migration Migration, script string, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		pst.logger.Error(err.Error())
+		return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		tx.Rollback()
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pst migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := pst.dbConnection.Conn(ctx)
+	if err != nil {
+		pst.logger.Error(err.Error())
+		return err
+	}
+	defer conn.Close()
+
+	if pst.dialect.lock != "" {
+		if _, err := conn.ExecContext(ctx, pst.dialect.lock, migrationsLockId); err != nil {
+			pst.logger.Error(err.Error())
+			return err
+		}
+		defer conn.ExecContext(context.Background(), pst.dialect.unlock, migrationsLockId)
+	}
+
+	return fn(conn)
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.Glob(fsys, dir+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		matches := migrationFileName.FindStringSubmatch(path.Base(file))
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", file)
+		}
+
+		version, _ := strconv.Atoi(matches[1])
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, matches[2])
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func NewMigrator(logger interfaces.ILogger, dbConnection *sql.DB, driver string) IMigrator {
+	dialect := dialectFor(driver)
+
+	migrations, err := loadMigrations(embeddedMigrations, dialect.dir)
+	if err != nil {
+		panic(err)
+	}
+
+	return migrator{
+		logger,
+		dbConnection,
+		dialect,
+		migrations,
+	}
+}
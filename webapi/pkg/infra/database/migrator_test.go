@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"webapi/pkg/infra/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Load_Embedded_Migrations_In_Order(t *testing.T) {
+	migrations, err := loadMigrations(embeddedMigrations, postgresDialect.dir)
+
+	assert.NoError(t, err)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func Test_Should_Have_Every_Migration_For_Sqlite(t *testing.T) {
+	postgres, err := loadMigrations(embeddedMigrations, postgresDialect.dir)
+	assert.NoError(t, err)
+	sqlite, err := loadMigrations(embeddedMigrations, sqliteDialect.dir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(postgres), len(sqlite))
+	for i := range postgres {
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+	}
+}
+
+func Test_Should_Apply_Sqlite_Migrations_To_Embedded_Database(t *testing.T) {
+	openConnetion = sql.Open
+	db, err := GetConnection(SqliteDriver, SqliteConnectionString(":memory:"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sut := NewMigrator(logger.NewLoggerSpy(), db, SqliteDriver)
+
+	applied, err := sut.Up(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, applied, 9)
+
+	reverted, err := sut.Down(context.Background(), len(applied))
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 9)
+}
+
+func Test_Should_Refuse_Migration_Without_Down_File(t *testing.T) {
+	_, err := loadMigrations(fstest.MapFS{
+		"migrations/0001_create_users_table.up.sql": {Data: []byte("CREATE TABLE users ();")},
+	}, postgresDialect.dir)
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Refuse_Invalid_Migration_File_Name(t *testing.T) {
+	_, err := loadMigrations(fstest.MapFS{
+		"migrations/create_users_table.sql": {Data: []byte("CREATE TABLE users ();")},
+	}, postgresDialect.dir)
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Apply_Pending_Migrations(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.expectApplied(1)
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("CREATE TABLE sessions").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "create_sessions_table").WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectCommit()
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := sut.migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 2, applied[0].Version)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Rollback_Failed_Migration(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.expectApplied()
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("CREATE TABLE users").WillReturnError(errors.New("some error"))
+	sut.sqlMock.ExpectRollback()
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := sut.migrator.Up(context.Background())
+
+	assert.Error(t, err)
+	assert.Empty(t, applied)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Revert_Latest_Migration(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.expectApplied(1, 2)
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("DROP TABLE sessions").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectCommit()
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reverted, err := sut.migrator.Down(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, 2, reverted[0].Version)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Redo_Latest_Migration(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.expectApplied(1, 2)
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("DROP TABLE sessions").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectCommit()
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.expectApplied(1)
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("CREATE TABLE sessions").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "create_sessions_table").WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectCommit()
+	sut.sqlMock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := sut.migrator.Redo(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Report_Migration_Status(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.expectApplied(1)
+
+	statuses, err := sut.migrator.Status(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func Test_Should_List_Pending_Migrations(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.expectApplied(1, 2)
+
+	pending, err := sut.migrator.Pending(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func Test_Should_Return_Error_When_Schema_Migrations_Cannot_Be_Read(t *testing.T) {
+	sut := newMigratorToTest()
+
+	sut.sqlMock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnError(errors.New("some error"))
+
+	_, err := sut.migrator.Pending(context.Background())
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Refuse_To_Index_Emails_Shared_Once_Normalized(t *testing.T) {
+	openConnetion = sql.Open
+	db, err := GetConnection(SqliteDriver, SqliteConnectionString(":memory:"))
+	assert.NoError(t, err)
+	defer db.Close()
+	sut := NewMigrator(logger.NewLoggerSpy(), db, SqliteDriver)
+	_, err = sut.Up(context.Background())
+	assert.NoError(t, err)
+	_, err = sut.Down(context.Background(), 2)
+	assert.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users (id, name, email, password) VALUES (1, 'Name', 'Some@Email.com', 'hash'), (2, 'Name', ' some@email.com', 'hash'), (3, 'Name', 'other@email.com', 'hash')`)
+	assert.NoError(t, err)
+
+	_, err = sut.Up(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "merge them before migrating")
+}
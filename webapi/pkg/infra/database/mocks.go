@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"testing/fstest"
+	"time"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/infra/logger"
+	"webapi/pkg/infra/telemetry"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+)
+
+type migratorToTest struct {
+	migrator IMigrator
+	sqlMock  sqlmock.Sqlmock
+}
+
+var migrationsToTest = fstest.MapFS{
+	"migrations/0001_create_users_table.up.sql":      {Data: []byte("CREATE TABLE users ();")},
+	"migrations/0001_create_users_table.down.sql":    {Data: []byte("DROP TABLE users;")},
+	"migrations/0002_create_sessions_table.up.sql":   {Data: []byte("CREATE TABLE sessions ();")},
+	"migrations/0002_create_sessions_table.down.sql": {Data: []byte("DROP TABLE sessions;")},
+}
+
+func newMigratorToTest() migratorToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	migrations, err := loadMigrations(migrationsToTest, postgresDialect.dir)
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when loading migrations", err)
+	}
+
+	return migratorToTest{
+		migrator{logger.NewLoggerSpy(), db, postgresDialect, migrations},
+		mock,
+	}
+}
+
+func (pst migratorToTest) expectApplied(versions ...int) {
+	pst.sqlMock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := pst.sqlMock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Now())
+	}
+
+	pst.sqlMock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+}
+
+type transactionManagerToTest struct {
+	manager      interfaces.ITransactionManager
+	dbConnection *sql.DB
+	sqlMock      sqlmock.Sqlmock
+}
+
+func newTransactionManagerToTest() transactionManagerToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return transactionManagerToTest{
+		NewTransactionManager(logger.NewLoggerSpy(), db, telemetrySpy{}),
+		db,
+		mock,
+	}
+}
+
+type statementCacheToTest struct {
+	statements   IStatementCache
+	dbConnection *sql.DB
+	sqlMock      sqlmock.Sqlmock
+}
+
+func newStatementCacheToTest() statementCacheToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return statementCacheToTest{NewStatementCache(db), db, mock}
+}
+
+type telemetrySpy struct{}
+
+func (telemetrySpy) GinMiddle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {}
+}
+func (telemetrySpy) InstrumentQuery(ctx context.Context, sqlType string, sql string) opentracing.Span {
+	return opentracing.StartSpan("")
+}
+func (telemetrySpy) InstrumentGRPCClient(ctx context.Context, clientName string) (opentracing.Span, context.Context) {
+	return nil, nil
+}
+func (telemetrySpy) InstrumentAMQPPublisher(ctx context.Context, exchangeName, queueName string) (opentracing.Span, context.Context) {
+	return nil, nil
+}
+func (telemetrySpy) StartSpanFromRequest(header http.Header) opentracing.Span {
+	return opentracing.StartSpan("")
+}
+func (telemetrySpy) Inject(span opentracing.Span, request *http.Request) error {
+	return nil
+}
+func (telemetrySpy) InjectAMQPHeader(header map[string]interface{}, ctx context.Context) error {
+	return nil
+}
+func (telemetrySpy) GetTraceparenteFromSpan(span opentracing.Span) string {
+	return ""
+}
+func (telemetrySpy) Extract(header http.Header) (opentracing.SpanContext, error) {
+	return nil, nil
+}
+func (telemetrySpy) Dispatch() {}
+func (telemetrySpy) GetTracer() opentracing.Tracer {
+	return nil
+}
+
+var _ telemetry.ITelemetry = telemetrySpy{}
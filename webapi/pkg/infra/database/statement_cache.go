@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// IStatementCache hands out prepared statements that live as long as the
+// connection pool. Callers must not close them; Close releases all of them on
+// shutdown.
+type IStatementCache interface {
+	Prepare(ctx context.Context, query string) (*sql.Stmt, error)
+	Close() error
+}
+
+type statementCache struct {
+	dbConnection *sql.DB
+
+	mu         sync.Mutex
+	statements map[string]*sql.Stmt
+}
+
+// Prepare prepares query the first time it is seen and reuses the statement
+// afterwards. Inside a transaction the statement is bound to it, and
+// database/sql closes that copy when the transaction ends.
+func (pst *statementCache) Prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	current, inTransaction := ctx.Value(transactionKey{}).(*transaction)
+
+	pst.mu.Lock()
+	stmt, ok := pst.statements[query]
+	pst.mu.Unlock()
+
+	switch {
+	case ok && inTransaction:
+		return current.tx.StmtContext(ctx, stmt), nil
+	case ok:
+		return stmt, nil
+	case inTransaction:
+		// Preparing on the pool here would need a second connection while the
+		// transaction holds one, which never comes with a single connection pool.
+		return current.tx.PrepareContext(ctx, query)
+	}
+
+	return pst.statement(ctx, query)
+}
+
+func (pst *statementCache) statement(ctx context.Context, query string) (*sql.Stmt, error) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	if stmt, ok := pst.statements[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := pst.dbConnection.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pst.statements[query] = stmt
+	return stmt, nil
+}
+
+func (pst *statementCache) Close() error {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	var firstErr error
+	for query, stmt := range pst.statements {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(pst.statements, query)
+	}
+
+	return firstErr
+}
+
+func NewStatementCache(dbConnection *sql.DB) IStatementCache {
+	return &statementCache{
+		dbConnection: dbConnection,
+		statements:   map[string]*sql.Stmt{},
+	}
+}
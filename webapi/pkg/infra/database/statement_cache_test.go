@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"webapi/pkg/infra/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StatementCache_Should_Prepare_Each_Query_Once(t *testing.T) {
+	sut := newStatementCacheToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT 1").WillBeClosed()
+
+	first, err := sut.statements.Prepare(context.Background(), "SELECT 1")
+	assert.NoError(t, err)
+	second, err := sut.statements.Prepare(context.Background(), "SELECT 1")
+	assert.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.NoError(t, sut.statements.Close())
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_StatementCache_Should_Not_Keep_Failed_Prepares(t *testing.T) {
+	sut := newStatementCacheToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT 1").WillReturnError(errors.New("some error"))
+	sut.sqlMock.ExpectPrepare("SELECT 1")
+
+	_, err := sut.statements.Prepare(context.Background(), "SELECT 1")
+	assert.Error(t, err)
+	_, err = sut.statements.Prepare(context.Background(), "SELECT 1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_StatementCache_Should_Bind_Statement_To_Transaction(t *testing.T) {
+	sut := newStatementCacheToTest()
+	manager := NewTransactionManager(logger.NewLoggerSpy(), sut.dbConnection, telemetrySpy{})
+
+	sut.sqlMock.ExpectPrepare("SELECT 1")
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"one"}).AddRow(1))
+	sut.sqlMock.ExpectCommit()
+
+	outside, _ := sut.statements.Prepare(context.Background(), "SELECT 1")
+
+	err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		inside, err := sut.statements.Prepare(ctx, "SELECT 1")
+		if err != nil {
+			return err
+		}
+
+		assert.NotSame(t, outside, inside)
+
+		var one int
+		return inside.QueryRowContext(ctx).Scan(&one)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_StatementCache_Should_Prepare_On_Transaction_When_Not_Cached(t *testing.T) {
+	sut := newStatementCacheToTest()
+	manager := NewTransactionManager(logger.NewLoggerSpy(), sut.dbConnection, telemetrySpy{})
+	sut.dbConnection.SetMaxOpenConns(1)
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectPrepare("SELECT 1")
+	sut.sqlMock.ExpectCommit()
+
+	err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := sut.statements.Prepare(ctx, "SELECT 1")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/infra/telemetry"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+type transactionKey struct{}
+
+type transaction struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+// IExecutor is what repositories run their statements on: the transaction in
+// the context when there is one, the connection pool otherwise.
+type IExecutor interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func Executor(ctx context.Context, dbConnection *sql.DB) IExecutor {
+	if current, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		return current.tx
+	}
+
+	return dbConnection
+}
+
+type transactionManager struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst transactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if current, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		return pst.withinSavepoint(ctx, current, fn)
+	}
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_TRANSACTION, "BEGIN")
+	defer span.Finish()
+
+	tx, err := pst.dbConnection.BeginTx(ctx, nil)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	txCtx := context.WithValue(opentracing.ContextWithSpan(ctx, span), transactionKey{}, &transaction{tx: tx})
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		span.SetTag("error", true)
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			pst.logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (pst transactionManager) withinSavepoint(ctx context.Context, current *transaction, fn func(ctx context.Context) error) error {
+	current.savepoints++
+	name := fmt.Sprintf("sp_%d", current.savepoints)
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SAVEPOINT, "SAVEPOINT "+name)
+	defer span.Finish()
+
+	if _, err := current.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			current.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(opentracing.ContextWithSpan(ctx, span)); err != nil {
+		span.SetTag("error", true)
+		if _, rollbackErr := current.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			pst.logger.Error(rollbackErr.Error())
+		}
+		return err
+	}
+
+	if _, err := current.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewTransactionManager(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.ITransactionManager {
+	return transactionManager{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Executor_Should_Use_Connection_Outside_Transaction(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	assert.Equal(t, sut.dbConnection, Executor(context.Background(), sut.dbConnection))
+}
+
+func Test_Should_Commit_Transaction_When_Fn_Succeeds(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	sut.sqlMock.ExpectCommit()
+
+	err := sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		assert.NotEqual(t, sut.dbConnection, Executor(ctx, sut.dbConnection))
+
+		_, err := Executor(ctx, sut.dbConnection).ExecContext(ctx, "INSERT INTO users")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Rollback_Transaction_When_Fn_Fails(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectRollback()
+
+	err := sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return errors.New("some error")
+	})
+
+	assert.EqualError(t, err, "some error")
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Rollback_Transaction_And_Repanic(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectRollback()
+
+	assert.Panics(t, func() {
+		sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			panic("some panic")
+		})
+	})
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Return_Error_When_Begin_Fails(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin().WillReturnError(errors.New("some error"))
+
+	err := sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Release_Savepoint_When_Nested_Fn_Succeeds(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectCommit()
+
+	err := sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return sut.manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Rollback_To_Savepoint_When_Nested_Fn_Fails(t *testing.T) {
+	sut := newTransactionManagerToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectCommit()
+
+	err := sut.manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		nestedErr := sut.manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return errors.New("some error")
+		})
+		assert.Error(t, nestedErr)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
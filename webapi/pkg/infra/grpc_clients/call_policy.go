@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultCallTimeout = time.Second * 3
+const defaultMaxRetries = 2
+const defaultRetryBackoff = time.Millisecond * 100
+const maxRetryBackoff = time.Second * 2
+
+var jitter = rand.Int63n
+
+// idempotentMethods can be sent again without side effects, so they are the
+// only calls retried. Creating a product twice would duplicate it.
+var idempotentMethods = map[string]bool{
+	"/inventory.Inventory/GetProductById":    true,
+	"/inventory.Inventory/GetProductsByType": true,
+	"/inventory.Inventory/GetProducts":       true,
+}
+
+// callPolicy bounds every inventory RPC by INVENTORY_MS_CALL_TIMEOUT instead of
+// the request context alone, and retries idempotent calls that failed because
+// the service was unreachable up to INVENTORY_MS_MAX_RETRIES times.
+type callPolicy struct {
+	timeout    time.Duration
+	maxRetries int
+	backoff    time.Duration
+}
+
+func callPolicyFromEnv() callPolicy {
+	return callPolicy{
+		timeout:    durationFromEnv("INVENTORY_MS_CALL_TIMEOUT", defaultCallTimeout),
+		maxRetries: intFromEnv("INVENTORY_MS_MAX_RETRIES", defaultMaxRetries),
+		backoff:    durationFromEnv("INVENTORY_MS_RETRY_BACKOFF", defaultRetryBackoff),
+	}
+}
+
+func (pst callPolicy) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	attempts := 1
+	if idempotentMethods[method] {
+		attempts += pst.maxRetries
+	}
+
+	err := pst.invoke(ctx, method, req, reply, cc, invoker, opts...)
+	for attempt := 1; attempt < attempts && pst.retryable(ctx, err); attempt++ {
+		if span := opentracing.SpanFromContext(ctx); span != nil {
+			span.LogKV("event", "retry", "attempt", attempt, "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(pst.backoffFor(attempt)):
+		}
+
+		err = pst.invoke(ctx, method, req, reply, cc, invoker, opts...)
+	}
+
+	return err
+}
+
+func (pst callPolicy) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	callCtx, cancel := context.WithTimeout(ctx, pst.timeout)
+	defer cancel()
+
+	return invoker(callCtx, method, req, reply, cc, opts...)
+}
+
+// retryable accepts failures where the service never answered. A deadline
+// only counts when it was this attempt's, not the caller's.
+func (pst callPolicy) retryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoffFor waits a random time up to an exponentially growing ceiling, so
+// replicas retrying together do not hit a restarting service in lockstep.
+func (pst callPolicy) backoffFor(attempt int) time.Duration {
+	ceiling := pst.backoff << (attempt - 1)
+	if ceiling <= 0 || ceiling > maxRetryBackoff {
+		ceiling = maxRetryBackoff
+	}
+
+	return time.Duration(jitter(int64(ceiling) + 1))
+}
+
+func intFromEnv(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value >= 0 {
+		return value
+	}
+
+	return fallback
+}
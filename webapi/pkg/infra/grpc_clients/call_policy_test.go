@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CallPolicy_Should_Retry_Idempotent_Calls_While_Service_Is_Unavailable(t *testing.T) {
+	t.Setenv("INVENTORY_MS_RETRY_BACKOFF", "1ms")
+	sut := newInventoryClientToTest(t)
+	sut.unavailable = 2
+
+	result, err := sut.client.GetProductById(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.Id)
+	assert.Equal(t, int32(3), sut.calls)
+}
+
+func Test_CallPolicy_Should_Give_Up_After_Max_Retries(t *testing.T) {
+	t.Setenv("INVENTORY_MS_RETRY_BACKOFF", "1ms")
+	t.Setenv("INVENTORY_MS_MAX_RETRIES", "1")
+	sut := newInventoryClientToTest(t)
+	sut.unavailable = 5
+
+	_, err := sut.client.GetProductById(context.Background(), "1")
+
+	assert.IsType(t, errors.ServiceUnavailableError{}, err)
+	assert.Equal(t, int32(2), sut.calls)
+}
+
+func Test_CallPolicy_Should_Not_Retry_Calls_That_Are_Not_Idempotent(t *testing.T) {
+	t.Setenv("INVENTORY_MS_RETRY_BACKOFF", "1ms")
+	sut := newInventoryClientToTest(t)
+	sut.unavailable = 1
+
+	_, err := sut.client.RegisterProduct(context.Background(), dtos.ProductDto{Title: "Title"})
+
+	assert.IsType(t, errors.ServiceUnavailableError{}, err)
+	assert.Equal(t, int32(1), sut.calls)
+}
+
+func Test_CallPolicy_Should_Bound_Each_Call_By_The_Call_Timeout(t *testing.T) {
+	t.Setenv("INVENTORY_MS_CALL_TIMEOUT", "20ms")
+	t.Setenv("INVENTORY_MS_MAX_RETRIES", "0")
+	sut := newInventoryClientToTest(t)
+	sut.delay = time.Millisecond * 200
+
+	started := time.Now()
+	_, err := sut.client.GetProductById(context.Background(), "1")
+
+	assert.IsType(t, errors.GatewayTimeoutError{}, err)
+	assert.Less(t, int64(time.Since(started)), int64(time.Millisecond*150))
+}
+
+func Test_CallPolicy_Should_Stop_Retrying_When_The_Caller_Gives_Up(t *testing.T) {
+	t.Setenv("INVENTORY_MS_RETRY_BACKOFF", "1s")
+	sut := newInventoryClientToTest(t)
+	sut.unavailable = 5
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	_, err := sut.client.GetProductById(ctx, "1")
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), sut.calls)
+}
+
+func Test_CallPolicy_Should_Jitter_Backoff_Below_An_Exponential_Ceiling(t *testing.T) {
+	defer func() { jitter = rand.Int63n }()
+	var ceilings []int64
+	jitter = func(n int64) int64 {
+		ceilings = append(ceilings, n-1)
+		return n - 1
+	}
+	sut := callPolicy{backoff: time.Millisecond * 100}
+
+	sut.backoffFor(1)
+	sut.backoffFor(2)
+	sut.backoffFor(10)
+
+	assert.Equal(t, []int64{int64(time.Millisecond * 100), int64(time.Millisecond * 200), int64(maxRetryBackoff)}, ceilings)
+}
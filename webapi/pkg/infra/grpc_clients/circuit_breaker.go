@@ -0,0 +1,224 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+const defaultBreakerFailures = 5
+const defaultBreakerOpenTimeout = time.Second * 30
+
+var now = time.Now
+
+type breakerState string
+
+const (
+	breakerClosed   breakerState = "closed"
+	breakerOpen     breakerState = "open"
+	breakerHalfOpen breakerState = "half-open"
+)
+
+// circuitBreaker opens after failureThreshold consecutive failures and then
+// rejects calls for openTimeout. The first call after that is let through as a
+// probe: success closes the breaker again, failure keeps it open.
+type circuitBreaker struct {
+	name             string
+	logger           interfaces.ILogger
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (pst *circuitBreaker) execute(ctx context.Context, call func() error) error {
+	if err := pst.allow(ctx); err != nil {
+		return err
+	}
+
+	err := call()
+	pst.record(ctx, err)
+	return err
+}
+
+func (pst *circuitBreaker) allow(ctx context.Context) error {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	if pst.state == breakerOpen {
+		remaining := pst.openTimeout - now().Sub(pst.openedAt)
+		if remaining > 0 {
+			return pst.reject(ctx, remaining)
+		}
+
+		pst.transition(ctx, breakerHalfOpen)
+	}
+
+	if pst.state == breakerHalfOpen {
+		if pst.probing {
+			return pst.reject(ctx, time.Second)
+		}
+		pst.probing = true
+	}
+
+	return nil
+}
+
+func (pst *circuitBreaker) record(ctx context.Context, err error) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	pst.probing = false
+
+	// A caller giving up says nothing about the service.
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	if !isOutage(err) {
+		pst.failures = 0
+		if pst.state != breakerClosed {
+			pst.transition(ctx, breakerClosed)
+		}
+		return
+	}
+
+	pst.failures++
+	if pst.state == breakerHalfOpen || pst.failures >= pst.failureThreshold {
+		pst.openedAt = now()
+		if pst.state != breakerOpen {
+			pst.transition(ctx, breakerOpen)
+		}
+	}
+}
+
+func (pst *circuitBreaker) reject(ctx context.Context, retryAfter time.Duration) error {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag("circuit_breaker.state", string(pst.state))
+	}
+
+	return errors.NewServiceUnavailableError(fmt.Sprintf("%s is unavailable", pst.name), retryAfter)
+}
+
+func (pst *circuitBreaker) transition(ctx context.Context, to breakerState) {
+	from := pst.state
+	pst.state = to
+
+	message := fmt.Sprintf("[CircuitBreaker] %s %s -> %s", pst.name, from, to)
+	if to == breakerOpen {
+		pst.logger.Warn(message)
+	} else {
+		pst.logger.Info(message)
+	}
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.LogKV("event", "circuit_breaker", "name", pst.name, "from", string(from), "to", string(to))
+	}
+}
+
+// isOutage tells failures of the service apart from answers it gave, a
+// missing product says nothing about its health.
+func isOutage(err error) bool {
+	switch err.(type) {
+	case errors.ServiceUnavailableError, errors.GatewayTimeoutError, errors.InternalError:
+		return true
+	default:
+		return false
+	}
+}
+
+func newCircuitBreaker(name string, logger interfaces.ILogger, failureThreshold int, openTimeout time.Duration) *circuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &circuitBreaker{
+		name:             name,
+		logger:           logger,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		state:            breakerClosed,
+	}
+}
+
+// circuitBreakerInventoryClient fails fast with a 503 while the inventory
+// service is known to be down, instead of making every request wait for it.
+type circuitBreakerInventoryClient struct {
+	client  interfaces.IIventoryClient
+	breaker *circuitBreaker
+}
+
+func (pst circuitBreakerInventoryClient) GetProductById(ctx context.Context, id string) (dtos.ProductDto, error) {
+	var result dtos.ProductDto
+	err := pst.breaker.execute(ctx, func() (err error) {
+		result, err = pst.client.GetProductById(ctx, id)
+		return err
+	})
+
+	return result, err
+}
+
+func (pst circuitBreakerInventoryClient) GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	var result dtos.ProductsPageDto
+	err := pst.breaker.execute(ctx, func() (err error) {
+		result, err = pst.client.GetProducts(ctx, limit, offset)
+		return err
+	})
+
+	return result, err
+}
+
+func (pst circuitBreakerInventoryClient) GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	var result []dtos.ProductDto
+	err := pst.breaker.execute(ctx, func() (err error) {
+		result, err = pst.client.GetProductsByType(ctx, productType)
+		return err
+	})
+
+	return result, err
+}
+
+func (pst circuitBreakerInventoryClient) UpdateProduct(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	var result dtos.ProductDto
+	err := pst.breaker.execute(ctx, func() (err error) {
+		result, err = pst.client.UpdateProduct(ctx, dto)
+		return err
+	})
+
+	return result, err
+}
+
+func (pst circuitBreakerInventoryClient) RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error) {
+	var result dtos.ProductDto
+	err := pst.breaker.execute(ctx, func() (err error) {
+		result, err = pst.client.RegisterProduct(ctx, product)
+		return err
+	})
+
+	return result, err
+}
+
+// NewCircuitBreakerInventoryClient wraps client with a breaker that opens after
+// INVENTORY_MS_BREAKER_FAILURES consecutive outages and stays open for
+// INVENTORY_MS_BREAKER_OPEN_TIMEOUT.
+func NewCircuitBreakerInventoryClient(logger interfaces.ILogger, client interfaces.IIventoryClient) interfaces.IIventoryClient {
+	return circuitBreakerInventoryClient{
+		client,
+		newCircuitBreaker(
+			"inventory",
+			logger,
+			intFromEnv("INVENTORY_MS_BREAKER_FAILURES", defaultBreakerFailures),
+			durationFromEnv("INVENTORY_MS_BREAKER_OPEN_TIMEOUT", defaultBreakerOpenTimeout),
+		),
+	}
+}
@@ -0,0 +1,114 @@
+package clients
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCircuitBreakerToTest(t *testing.T, stub *inventoryClientStub) (circuitBreakerInventoryClient, interface{}) {
+	t.Setenv("INVENTORY_MS_BREAKER_FAILURES", "2")
+	t.Setenv("INVENTORY_MS_BREAKER_OPEN_TIMEOUT", "30s")
+	loggerSpy := logger.NewLoggerSpy()
+
+	return NewCircuitBreakerInventoryClient(loggerSpy, stub).(circuitBreakerInventoryClient), loggerSpy
+}
+
+func Test_CircuitBreaker_Should_Fail_Fast_Once_Open(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	stub := &inventoryClientStub{err: errors.NewServiceUnavailableError("down", 0)}
+	sut, loggerSpy := newCircuitBreakerToTest(t, stub)
+
+	sut.GetProductById(context.Background(), "1")
+	sut.GetProductById(context.Background(), "1")
+	_, err := sut.GetProducts(context.Background(), 20, 0)
+
+	assert.Equal(t, 2, stub.calls)
+	assert.IsType(t, errors.ServiceUnavailableError{}, err)
+	assert.Equal(t, time.Second*30, err.(errors.ServiceUnavailableError).RetryAfter)
+	assert.Equal(t, 1, reflect.ValueOf(loggerSpy).Elem().FieldByName("WarnCallerCount").Interface())
+}
+
+func Test_CircuitBreaker_Should_Not_Count_Answers_From_The_Service(t *testing.T) {
+	stub := &inventoryClientStub{err: errors.NewNotFoundError("product not found")}
+	sut, _ := newCircuitBreakerToTest(t, stub)
+
+	for i := 0; i < 5; i++ {
+		sut.GetProductById(context.Background(), "1")
+	}
+
+	assert.Equal(t, 5, stub.calls)
+}
+
+func Test_CircuitBreaker_Should_Not_Count_Callers_Giving_Up(t *testing.T) {
+	stub := &inventoryClientStub{err: errors.NewInternalError("canceled")}
+	sut, _ := newCircuitBreakerToTest(t, stub)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 5; i++ {
+		sut.GetProductById(ctx, "1")
+	}
+
+	assert.Equal(t, 5, stub.calls)
+}
+
+func Test_CircuitBreaker_Should_Close_After_A_Successful_Probe(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	stub := &inventoryClientStub{err: errors.NewServiceUnavailableError("down", 0)}
+	sut, loggerSpy := newCircuitBreakerToTest(t, stub)
+	sut.GetProductById(context.Background(), "1")
+	sut.GetProductById(context.Background(), "1")
+
+	current = current.Add(time.Second * 30)
+	stub.err = nil
+	_, probeErr := sut.GetProductById(context.Background(), "1")
+	_, err := sut.GetProductById(context.Background(), "1")
+
+	assert.NoError(t, probeErr)
+	assert.NoError(t, err)
+	assert.Equal(t, breakerClosed, sut.breaker.state)
+	assert.Equal(t, 2, reflect.ValueOf(loggerSpy).Elem().FieldByName("InfoCallerCount").Interface())
+}
+
+func Test_CircuitBreaker_Should_Reopen_If_The_Probe_Fails(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	stub := &inventoryClientStub{err: errors.NewServiceUnavailableError("down", 0)}
+	sut, _ := newCircuitBreakerToTest(t, stub)
+	sut.GetProductById(context.Background(), "1")
+	sut.GetProductById(context.Background(), "1")
+
+	current = current.Add(time.Second * 30)
+	sut.GetProductById(context.Background(), "1")
+	_, err := sut.GetProductById(context.Background(), "1")
+
+	assert.Equal(t, 3, stub.calls)
+	assert.Equal(t, breakerOpen, sut.breaker.state)
+	assert.Equal(t, time.Second*30, err.(errors.ServiceUnavailableError).RetryAfter)
+}
+
+func Test_CircuitBreaker_Should_Let_One_Probe_Through_At_A_Time(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	sut, _ := newCircuitBreakerToTest(t, &inventoryClientStub{})
+	sut.breaker.state = breakerOpen
+	sut.breaker.openedAt = current.Add(-time.Minute)
+
+	first := sut.breaker.allow(context.Background())
+	second := sut.breaker.allow(context.Background())
+
+	assert.NoError(t, first)
+	assert.IsType(t, errors.ServiceUnavailableError{}, second)
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+)
+
+const defaultKeepaliveTime = time.Second * 30
+const defaultKeepaliveTimeout = time.Second * 10
+
+// The caller must Close the connection.
+func NewInventoryConnection(logger interfaces.ILogger, options ...grpc.DialOption) (*grpc.ClientConn, error) {
+	creds, err := inventoryCredentials(logger)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	serviceConfig, err := inventoryServiceConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	target, resolverOptions := inventoryTarget()
+
+	transport := grpc.WithInsecure()
+	if creds != nil {
+		transport = grpc.WithTransportCredentials(creds)
+	}
+
+	dialOptions := append([]grpc.DialOption{
+		transport,
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                durationFromEnv("INVENTORY_MS_KEEPALIVE_TIME", defaultKeepaliveTime),
+			Timeout:             durationFromEnv("INVENTORY_MS_KEEPALIVE_TIMEOUT", defaultKeepaliveTimeout),
+			PermitWithoutStream: true,
+		}),
+		grpc.WithUnaryInterceptor(callPolicyFromEnv().intercept),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+	}, append(resolverOptions, options...)...)
+
+	conn, err := grpc.Dial(target, dialOptions...)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	go logStateChanges(logger, conn)
+
+	return conn, nil
+}
+
+func logStateChanges(logger interfaces.ILogger, conn *grpc.ClientConn) {
+	state := conn.GetState()
+	for conn.WaitForStateChange(context.Background(), state) {
+		state = conn.GetState()
+
+		message := fmt.Sprintf("[InventoryConnection] %s", state)
+		if state == connectivity.TransientFailure {
+			logger.Warn(message)
+		} else {
+			logger.Info(message)
+		}
+
+		if state == connectivity.Shutdown {
+			return
+		}
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+
+	return fallback
+}
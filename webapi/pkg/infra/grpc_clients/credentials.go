@@ -0,0 +1,239 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"webapi/pkg/app/interfaces"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const defaultTlsReloadInterval = time.Second * 30
+
+var errPlaintextInProduction = errors.New("INVENTORY_MS_TLS=false is not allowed when GO_ENV=production")
+
+// tlsSource items are either a watched file or PEM inlined in the env.
+type tlsSource struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	ca         string
+	cert       string
+	key        string
+	serverName string
+}
+
+func tlsSourceFromEnv() tlsSource {
+	return tlsSource{
+		caFile:     os.Getenv("INVENTORY_MS_TLS_CA_FILE"),
+		certFile:   os.Getenv("INVENTORY_MS_TLS_CERT_FILE"),
+		keyFile:    os.Getenv("INVENTORY_MS_TLS_KEY_FILE"),
+		ca:         os.Getenv("INVENTORY_MS_TLS_CA"),
+		cert:       os.Getenv("INVENTORY_MS_TLS_CERT"),
+		key:        os.Getenv("INVENTORY_MS_TLS_KEY"),
+		serverName: os.Getenv("INVENTORY_MS_TLS_SERVER_NAME"),
+	}
+}
+
+func (pst tlsSource) config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: pst.serverName,
+	}
+
+	ca, err := pst.read(pst.caFile, pst.ca)
+	if err != nil {
+		return nil, err
+	}
+	if ca != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("inventory TLS CA bundle has no valid certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	cert, err := pst.read(pst.certFile, pst.cert)
+	if err != nil {
+		return nil, err
+	}
+	key, err := pst.read(pst.keyFile, pst.key)
+	if err != nil {
+		return nil, err
+	}
+	if (cert == nil) != (key == nil) {
+		return nil, errors.New("inventory TLS client certificate and key must be set together")
+	}
+	if cert != nil {
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+
+	return config, nil
+}
+
+func (pst tlsSource) read(file, inline string) ([]byte, error) {
+	if file != "" {
+		return ioutil.ReadFile(file)
+	}
+	if inline != "" {
+		return []byte(inline), nil
+	}
+
+	return nil, nil
+}
+
+func (pst tlsSource) snapshot() (string, error) {
+	var entries []string
+	for _, path := range []string{pst.caFile, pst.certFile, pst.keyFile} {
+		if path == "" {
+			continue
+		}
+
+		file, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, fmt.Sprintf("%s:%d:%d", path, file.Size(), file.ModTime().UnixNano()))
+	}
+
+	sort.Strings(entries)
+	return strings.Join(entries, ","), nil
+}
+
+// reloadingCredentials hands every new handshake the latest tls.Config.
+type reloadingCredentials struct {
+	logger interfaces.ILogger
+	source tlsSource
+
+	mu       sync.RWMutex
+	config   *tls.Config
+	snapshot string
+}
+
+func (pst *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(pst.current()).ClientHandshake(ctx, authority, rawConn)
+}
+
+func (pst *reloadingCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("inventory credentials only dial the service")
+}
+
+func (pst *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(pst.current()).Info()
+}
+
+func (pst *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return pst
+}
+
+func (pst *reloadingCredentials) OverrideServerName(serverName string) error {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	pst.source.serverName = serverName
+	pst.config = pst.config.Clone()
+	pst.config.ServerName = serverName
+	return nil
+}
+
+func (pst *reloadingCredentials) current() *tls.Config {
+	pst.mu.RLock()
+	defer pst.mu.RUnlock()
+
+	return pst.config.Clone()
+}
+
+func (pst *reloadingCredentials) reloadIfChanged() error {
+	snapshot, err := pst.source.snapshot()
+	if err != nil {
+		return err
+	}
+
+	pst.mu.RLock()
+	unchanged := snapshot == pst.snapshot
+	pst.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	config, err := pst.source.config()
+	if err != nil {
+		return err
+	}
+
+	pst.mu.Lock()
+	pst.config = config
+	pst.snapshot = snapshot
+	pst.mu.Unlock()
+
+	pst.logger.Info("[InventoryCredentials::Reload] TLS certificates reloaded")
+	return nil
+}
+
+// A failed reload keeps the previous certificates.
+func (pst *reloadingCredentials) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := pst.reloadIfChanged(); err != nil {
+			pst.logger.Error(err.Error())
+		}
+	}
+}
+
+func newReloadingCredentials(logger interfaces.ILogger, source tlsSource) (*reloadingCredentials, error) {
+	snapshot, err := source.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := source.config()
+	if err != nil {
+		return nil, err
+	}
+
+	return &reloadingCredentials{
+		logger:   logger,
+		source:   source,
+		config:   config,
+		snapshot: snapshot,
+	}, nil
+}
+
+// A nil result means plaintext.
+func inventoryCredentials(logger interfaces.ILogger) (credentials.TransportCredentials, error) {
+	if os.Getenv("INVENTORY_MS_TLS") == "false" {
+		if os.Getenv("GO_ENV") == "production" {
+			return nil, errPlaintextInProduction
+		}
+
+		logger.Warn("[InventoryConnection] TLS is disabled, talking to the inventory service in plaintext")
+		return nil, nil
+	}
+
+	creds, err := newReloadingCredentials(logger, tlsSourceFromEnv())
+	if err != nil {
+		return nil, err
+	}
+
+	go creds.watch(durationFromEnv("INVENTORY_MS_TLS_RELOAD_INTERVAL", defaultTlsReloadInterval))
+
+	return creds, nil
+}
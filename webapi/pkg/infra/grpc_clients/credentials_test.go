@@ -0,0 +1,174 @@
+package clients
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func getProductOverTls(t *testing.T, address string) error {
+	t.Setenv("INVENTORY_MS_URI", address)
+	conn, err := NewInventoryConnection(logger.NewLoggerSpy())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = NewInventoryClient(logger.NewLoggerSpy(), telemetrySpy{}, conn).GetProductById(ctx, "1")
+	return err
+}
+
+func getProductWithCredentials(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err = NewInventoryClient(logger.NewLoggerSpy(), telemetrySpy{}, conn).GetProductById(ctx, "1")
+	return err
+}
+
+func Test_InventoryCredentials_Should_Complete_Tls_Handshake(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	address := newTlsInventoryServerToTest(t, serverCA, nil)
+	t.Setenv("INVENTORY_MS_TLS_CA_FILE", writeFileToTest(t, dir, "ca.pem", serverCA.pem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "inventory.local")
+
+	assert.NoError(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Accept_Inline_Pem(t *testing.T) {
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	address := newTlsInventoryServerToTest(t, serverCA, nil)
+	t.Setenv("INVENTORY_MS_TLS_CA", string(serverCA.pem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "inventory.local")
+
+	assert.NoError(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Reject_Server_Signed_By_Unknown_CA(t *testing.T) {
+	dir := t.TempDir()
+	address := newTlsInventoryServerToTest(t, newCertificateAuthorityToTest(t, "server ca"), nil)
+	t.Setenv("INVENTORY_MS_TLS_CA_FILE", writeFileToTest(t, dir, "ca.pem", newCertificateAuthorityToTest(t, "other ca").pem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "inventory.local")
+
+	assert.Error(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Reject_Server_Name_Mismatch(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	address := newTlsInventoryServerToTest(t, serverCA, nil)
+	t.Setenv("INVENTORY_MS_TLS_CA_FILE", writeFileToTest(t, dir, "ca.pem", serverCA.pem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "other.local")
+
+	assert.Error(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Complete_Mutual_Tls_Handshake(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	clientCA := newCertificateAuthorityToTest(t, "client ca")
+	address := newTlsInventoryServerToTest(t, serverCA, &clientCA)
+	certPem, keyPem := clientCA.issue(t, "webapi")
+	t.Setenv("INVENTORY_MS_TLS_CA_FILE", writeFileToTest(t, dir, "ca.pem", serverCA.pem))
+	t.Setenv("INVENTORY_MS_TLS_CERT_FILE", writeFileToTest(t, dir, "client.pem", certPem))
+	t.Setenv("INVENTORY_MS_TLS_KEY_FILE", writeFileToTest(t, dir, "client.key", keyPem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "inventory.local")
+
+	assert.NoError(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Fail_Mutual_Tls_Without_Client_Certificate(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	clientCA := newCertificateAuthorityToTest(t, "client ca")
+	address := newTlsInventoryServerToTest(t, serverCA, &clientCA)
+	t.Setenv("INVENTORY_MS_TLS_CA_FILE", writeFileToTest(t, dir, "ca.pem", serverCA.pem))
+	t.Setenv("INVENTORY_MS_TLS_SERVER_NAME", "inventory.local")
+
+	assert.Error(t, getProductOverTls(t, address))
+}
+
+func Test_InventoryCredentials_Should_Use_Reloaded_Client_Certificate(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	clientCA := newCertificateAuthorityToTest(t, "client ca")
+	address := newTlsInventoryServerToTest(t, serverCA, &clientCA)
+	untrustedCert, untrustedKey := newCertificateAuthorityToTest(t, "other ca").issue(t, "webapi")
+	source := tlsSource{
+		caFile:     writeFileToTest(t, dir, "ca.pem", serverCA.pem),
+		certFile:   writeFileToTest(t, dir, "client.pem", untrustedCert),
+		keyFile:    writeFileToTest(t, dir, "client.key", untrustedKey),
+		serverName: "inventory.local",
+	}
+	creds, err := newReloadingCredentials(logger.NewLoggerSpy(), source)
+	assert.NoError(t, err)
+
+	before := getProductWithCredentials(t, address, creds)
+
+	certPem, keyPem := clientCA.issue(t, "webapi")
+	writeFileToTest(t, dir, "client.pem", certPem)
+	writeFileToTest(t, dir, "client.key", keyPem)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(source.certFile, later, later)
+
+	assert.Error(t, before)
+	assert.NoError(t, creds.reloadIfChanged())
+	assert.NoError(t, getProductWithCredentials(t, address, creds))
+}
+
+func Test_InventoryCredentials_Should_Keep_Previous_Certificates_If_Reload_Fails(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newCertificateAuthorityToTest(t, "server ca")
+	source := tlsSource{caFile: writeFileToTest(t, dir, "ca.pem", serverCA.pem)}
+	creds, err := newReloadingCredentials(logger.NewLoggerSpy(), source)
+	assert.NoError(t, err)
+
+	writeFileToTest(t, dir, "ca.pem", []byte("not a certificate, or a half-written one"))
+
+	assert.Error(t, creds.reloadIfChanged())
+	assert.NotNil(t, creds.current().RootCAs)
+}
+
+func Test_InventoryCredentials_Should_Refuse_Plaintext_In_Production(t *testing.T) {
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	t.Setenv("GO_ENV", "production")
+
+	_, err := inventoryCredentials(logger.NewLoggerSpy())
+
+	assert.ErrorIs(t, err, errPlaintextInProduction)
+}
+
+func Test_InventoryCredentials_Should_Allow_Plaintext_In_Development(t *testing.T) {
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	t.Setenv("GO_ENV", "development")
+
+	creds, err := inventoryCredentials(logger.NewLoggerSpy())
+
+	assert.NoError(t, err)
+	assert.Nil(t, creds)
+}
+
+func Test_InventoryCredentials_Should_Require_Client_Certificate_And_Key_Together(t *testing.T) {
+	dir := t.TempDir()
+	certPem, _ := newCertificateAuthorityToTest(t, "client ca").issue(t, "webapi")
+
+	_, err := newReloadingCredentials(logger.NewLoggerSpy(), tlsSource{certFile: writeFileToTest(t, dir, "client.pem", certPem)})
+
+	assert.Error(t, err)
+}
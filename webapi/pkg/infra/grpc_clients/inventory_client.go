@@ -2,15 +2,12 @@ package clients
 
 import (
 	"context"
-	"os"
-	"webapi/pkg/app/errors"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/infra/grpc_clients/proto"
 	"webapi/pkg/infra/telemetry"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"google.golang.org/grpc"
 )
@@ -18,21 +15,14 @@ import (
 type inventoryClient struct {
 	logger    interfaces.ILogger
 	telemetry telemetry.ITelemetry
+	client    proto.InventoryClient
 }
 
 func (pst inventoryClient) GetProductById(ctx context.Context, id string) (dtos.ProductDto, error) {
-	conn, err := connectToGrpcServer(ctx)
-	if err != nil {
-		return dtos.ProductDto{}, err
-	}
-	defer conn.Close()
-
 	span, spanCtx := pst.telemetry.InstrumentGRPCClient(ctx, "Inventory Client")
 	defer span.Finish()
 
-	client := proto.NewInventoryClient(conn)
-
-	result, err := client.GetProductById(spanCtx, &proto.GetByIdRequest{
+	result, err := pst.client.GetProductById(spanCtx, &proto.GetByIdRequest{
 		Id: id,
 	})
 
@@ -44,19 +34,44 @@ func (pst inventoryClient) GetProductById(ctx context.Context, id string) (dtos.
 	return toProduct(result), err
 }
 
-func (pst inventoryClient) RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error) {
-	conn, err := connectToGrpcServer(ctx)
+func (pst inventoryClient) GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	span, spanCtx := pst.telemetry.InstrumentGRPCClient(ctx, "Inventory Client")
+	defer span.Finish()
+
+	result, err := pst.client.GetProducts(spanCtx, &proto.GetProductsRequest{
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+
 	if err != nil {
-		return dtos.ProductDto{}, err
+		span.SetTag("error", true)
+		return dtos.ProductsPageDto{}, mapErrorToHttp(err)
 	}
-	defer conn.Close()
 
+	return toProductsPage(result, limit, offset), nil
+}
+
+func (pst inventoryClient) GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
 	span, spanCtx := pst.telemetry.InstrumentGRPCClient(ctx, "Inventory Client")
 	defer span.Finish()
 
-	client := proto.NewInventoryClient(conn)
+	result, err := pst.client.GetProductsByType(spanCtx, &proto.GetByTypeRequest{
+		Type: productType,
+	})
 
-	result, err := client.CreateProduct(spanCtx, toCreateProductRequest(product))
+	if err != nil {
+		span.SetTag("error", true)
+		return nil, mapErrorToHttp(err)
+	}
+
+	return toProducts(result), nil
+}
+
+func (pst inventoryClient) RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error) {
+	span, spanCtx := pst.telemetry.InstrumentGRPCClient(ctx, "Inventory Client")
+	defer span.Finish()
+
+	result, err := pst.client.CreateProduct(spanCtx, toCreateProductRequest(product))
 
 	if err != nil {
 		span.SetTag("error", true)
@@ -66,15 +81,18 @@ func (pst inventoryClient) RegisterProduct(ctx context.Context, product dtos.Pro
 	return toProduct(result), err
 }
 
-func connectToGrpcServer(ctx context.Context) (*grpc.ClientConn, error) {
-	gRPCConfigs := []grpc.DialOption{
-		grpc.WithInsecure(),
-	}
-	conn, err := grpc.DialContext(ctx, os.Getenv("INVENTORY_MS_URI"), gRPCConfigs...)
+func (pst inventoryClient) UpdateProduct(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	span, spanCtx := pst.telemetry.InstrumentGRPCClient(ctx, "Inventory Client")
+	defer span.Finish()
+
+	result, err := pst.client.UpdateProduct(spanCtx, toUpdateProductRequest(dto))
+
 	if err != nil {
-		return nil, errors.NewInternalError("error whiling connect in inventory gRPC")
+		span.SetTag("error", true)
+		err = mapErrorToHttp(err)
 	}
-	return conn, nil
+
+	return toProduct(result), err
 }
 
 func toCreateProductRequest(product dtos.ProductDto) *proto.CreateProductRequest {
@@ -89,6 +107,24 @@ func toCreateProductRequest(product dtos.ProductDto) *proto.CreateProductRequest
 	}
 }
 
+func toUpdateProductRequest(dto dtos.UpdateProductDto) *proto.UpdateProductRequest {
+	request := &proto.UpdateProductRequest{Id: dto.Id}
+	if dto.Tag != nil {
+		request.Tag = wrapperspb.String(*dto.Tag)
+	}
+	if dto.Title != nil {
+		request.Title = wrapperspb.String(*dto.Title)
+	}
+	if dto.Subtitle != nil {
+		request.Subtitle = wrapperspb.String(*dto.Subtitle)
+	}
+	if dto.AmountInStock != nil {
+		request.AmountInStock = wrapperspb.Int64(int64(*dto.AmountInStock))
+	}
+
+	return request
+}
+
 func toProduct(response *proto.ProductResponse) dtos.ProductDto {
 	if response == nil {
 		return dtos.ProductDto{}
@@ -109,20 +145,30 @@ func toProduct(response *proto.ProductResponse) dtos.ProductDto {
 	}
 }
 
-func mapErrorToHttp(grpcError error) error {
-	errStatus, _ := status.FromError(grpcError)
+func toProducts(response *proto.ProductsResponse) []dtos.ProductDto {
+	products := make([]dtos.ProductDto, 0, len(response.GetValue()))
+	for _, product := range response.GetValue() {
+		products = append(products, toProduct(product))
+	}
+
+	return products
+}
 
-	switch errStatus.Code() {
-	case codes.NotFound:
-		return errors.NewNotFoundError("product not found")
-	default:
-		return errors.NewInternalError("some error occur in grpc client request")
+func toProductsPage(response *proto.ProductsResponse, limit, offset int) dtos.ProductsPageDto {
+	return dtos.ProductsPageDto{
+		Products: toProducts(response),
+		Total:    int(response.GetTotal()),
+		Limit:    limit,
+		Offset:   offset,
 	}
 }
 
-func NewInventoryClient(logger interfaces.ILogger, telemetry telemetry.ITelemetry) interfaces.IIventoryClient {
+// NewInventoryClient issues every call on conn, which the container keeps open
+// for the lifetime of the process.
+func NewInventoryClient(logger interfaces.ILogger, telemetry telemetry.ITelemetry, conn grpc.ClientConnInterface) interfaces.IIventoryClient {
 	return inventoryClient{
 		logger,
 		telemetry,
+		proto.NewInventoryClient(conn),
 	}
 }
@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/connectivity"
+)
+
+func Test_InventoryClient_Should_Reuse_The_Connection_Between_Calls(t *testing.T) {
+	sut := newInventoryClientToTest(t)
+
+	_, firstErr := sut.client.GetProductById(context.Background(), "1")
+	_, secondErr := sut.client.GetProducts(context.Background(), 20, 0)
+
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 1, sut.listener.Accepted())
+}
+
+func Test_InventoryClient_Should_Map_NotFound(t *testing.T) {
+	sut := newInventoryClientToTest(t)
+
+	_, err := sut.client.GetProductById(context.Background(), "missing")
+
+	assert.IsType(t, errors.NotFoundError{}, err)
+}
+
+func Test_InventoryClient_Should_Return_The_Page_Total(t *testing.T) {
+	sut := newInventoryClientToTest(t)
+
+	page, err := sut.client.GetProducts(context.Background(), 1, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, 1)
+	assert.Equal(t, 21, page.Total)
+}
+
+func Test_InventoryClient_Should_Send_Only_The_Fields_Being_Updated(t *testing.T) {
+	sut := newInventoryClientToTest(t)
+	stock := 0
+
+	_, err := sut.client.UpdateProduct(context.Background(), dtos.UpdateProductDto{Id: "1", AmountInStock: &stock})
+
+	assert.NoError(t, err)
+	assert.Nil(t, sut.lastUpdate.Title)
+	assert.Equal(t, int64(0), sut.lastUpdate.AmountInStock.GetValue())
+}
+
+func Test_InventoryConnection_Should_Shut_Down_On_Close(t *testing.T) {
+	sut := newInventoryClientToTest(t)
+
+	sut.conn.Close()
+
+	assert.Equal(t, connectivity.Shutdown, sut.conn.GetState())
+}
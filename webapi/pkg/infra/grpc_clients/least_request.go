@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+const leastRequestBalancerName = "inventory_least_request"
+
+func init() {
+	balancer.Register(leastRequestBalancerBuilder{})
+}
+
+// leastRequestBalancerBuilder gives every connection its own picker builder,
+// so the calls in flight are counted per connection.
+type leastRequestBalancerBuilder struct{}
+
+func (leastRequestBalancerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pickerBuilder := &leastRequestPickerBuilder{inFlight: map[balancer.SubConn]*int64{}}
+
+	return base.NewBalancerBuilder(leastRequestBalancerName, pickerBuilder, base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+func (leastRequestBalancerBuilder) Name() string {
+	return leastRequestBalancerName
+}
+
+// leastRequestPickerBuilder keeps the counters of the replicas still ready
+// when the picker is rebuilt, calls already in flight stay accounted for.
+type leastRequestPickerBuilder struct {
+	mu       sync.Mutex
+	inFlight map[balancer.SubConn]*int64
+}
+
+func (pst *leastRequestPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	inFlight := map[balancer.SubConn]*int64{}
+	picker := &leastRequestPicker{}
+	for subConn := range info.ReadySCs {
+		counter, ok := pst.inFlight[subConn]
+		if !ok {
+			counter = new(int64)
+		}
+
+		inFlight[subConn] = counter
+		picker.subConns = append(picker.subConns, subConn)
+		picker.inFlight = append(picker.inFlight, counter)
+	}
+	pst.inFlight = inFlight
+
+	return picker
+}
+
+// leastRequestPicker sends each call to the replica with the fewest calls in
+// flight. Ties go round-robin so an idle pool still spreads the load.
+type leastRequestPicker struct {
+	subConns []balancer.SubConn
+	inFlight []*int64
+	next     uint32
+}
+
+func (pst *leastRequestPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	start := int(atomic.AddUint32(&pst.next, 1)) % len(pst.subConns)
+
+	picked := start
+	for i := 1; i < len(pst.subConns); i++ {
+		candidate := (start + i) % len(pst.subConns)
+		if atomic.LoadInt64(pst.inFlight[candidate]) < atomic.LoadInt64(pst.inFlight[picked]) {
+			picked = candidate
+		}
+	}
+
+	counter := pst.inFlight[picked]
+	atomic.AddInt64(counter, 1)
+
+	return balancer.PickResult{
+		SubConn: pst.subConns[picked],
+		Done: func(balancer.DoneInfo) {
+			atomic.AddInt64(counter, -1)
+		},
+	}, nil
+}
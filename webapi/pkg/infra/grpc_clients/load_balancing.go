@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/health"
+	"google.golang.org/grpc/resolver"
+)
+
+const staticScheme = "inventory-static"
+
+const (
+	roundRobinPolicy   = "round_robin"
+	leastRequestPolicy = "least_request"
+)
+
+// inventoryTarget resolves INVENTORY_MS_URI into the target to dial. A comma
+// separated list of addresses is served by staticResolverBuilder, anything
+// else, such as dns:///inventory:50051, is left to the resolvers gRPC ships.
+func inventoryTarget() (string, []grpc.DialOption) {
+	uri := os.Getenv("INVENTORY_MS_URI")
+	if !strings.Contains(uri, ",") {
+		return uri, nil
+	}
+
+	return fmt.Sprintf("%s:///%s", staticScheme, uri), []grpc.DialOption{grpc.WithResolvers(staticResolverBuilder{})}
+}
+
+// inventoryServiceConfig balances calls with INVENTORY_MS_LB_POLICY and only
+// sends them to replicas reporting SERVING for INVENTORY_MS_HEALTH_SERVICE
+// through the standard gRPC health checking protocol. Replicas that do not
+// implement it are treated as healthy.
+func inventoryServiceConfig() (string, error) {
+	policy := os.Getenv("INVENTORY_MS_LB_POLICY")
+	switch policy {
+	case "", roundRobinPolicy:
+		policy = roundRobinPolicy
+	case leastRequestPolicy:
+		policy = leastRequestBalancerName
+	default:
+		return "", fmt.Errorf("unknown inventory load balancing policy %q", policy)
+	}
+
+	return fmt.Sprintf(
+		`{"loadBalancingConfig":[{%q:{}}],"healthCheckConfig":{"serviceName":%q}}`,
+		policy,
+		os.Getenv("INVENTORY_MS_HEALTH_SERVICE"),
+	), nil
+}
+
+// staticResolverBuilder hands the addresses listed in the target to the
+// balancer once, they never change for the lifetime of the connection.
+type staticResolverBuilder struct{}
+
+func (staticResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	addresses := []resolver.Address{}
+	for _, address := range strings.Split(target.Endpoint, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, resolver.Address{Addr: address, ServerName: host})
+	}
+
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no inventory address in %q", target.Endpoint)
+	}
+
+	if err := cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		return nil, err
+	}
+
+	return staticResolver{}, nil
+}
+
+func (staticResolverBuilder) Scheme() string {
+	return staticScheme
+}
+
+type staticResolver struct{}
+
+func (staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (staticResolver) Close() {}
@@ -0,0 +1,149 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+// callUntil keeps calling until done holds or a second went by, replicas
+// become ready one by one after the connection is opened.
+func callUntil(t *testing.T, client interfaces.IIventoryClient, done func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !done() && time.Now().Before(deadline) {
+		if _, err := client.GetProductById(context.Background(), "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func allServed(replicas []inventoryReplicaToTest) func() bool {
+	return func() bool {
+		for _, replica := range replicas {
+			if replica.served() == 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func Test_LoadBalancing_Should_Spread_Calls_Across_Replicas(t *testing.T) {
+	for _, policy := range []string{roundRobinPolicy, leastRequestPolicy} {
+		replicas := newInventoryReplicasToTest(t, 3)
+		sut := newPooledInventoryClientToTest(t, policy, replicas)
+
+		callUntil(t, sut, allServed(replicas))
+
+		for _, replica := range replicas {
+			assert.NotZero(t, replica.served(), policy)
+		}
+	}
+}
+
+func Test_LoadBalancing_Should_Skip_Replicas_Not_Serving(t *testing.T) {
+	replicas := newInventoryReplicasToTest(t, 3)
+	replicas[0].setServing(false)
+	sut := newPooledInventoryClientToTest(t, roundRobinPolicy, replicas)
+
+	callUntil(t, sut, allServed(replicas[1:]))
+	for i := 0; i < 30; i++ {
+		_, err := sut.GetProductById(context.Background(), "1")
+		assert.NoError(t, err)
+	}
+
+	assert.Zero(t, replicas[0].served())
+
+	replicas[0].setServing(true)
+	callUntil(t, sut, allServed(replicas))
+
+	assert.NotZero(t, replicas[0].served())
+}
+
+func Test_LoadBalancing_Should_Fail_Over_When_A_Replica_Stops(t *testing.T) {
+	t.Setenv("INVENTORY_MS_RETRY_BACKOFF", "10ms")
+	replicas := newInventoryReplicasToTest(t, 2)
+	sut := newPooledInventoryClientToTest(t, roundRobinPolicy, replicas)
+	callUntil(t, sut, allServed(replicas))
+
+	replicas[0].server.Stop()
+	before := replicas[1].served()
+	for i := 0; i < 20; i++ {
+		_, err := sut.GetProductById(context.Background(), "1")
+		assert.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, replicas[1].served()-before, 20)
+}
+
+func Test_LeastRequestPicker_Should_Pick_The_Replica_With_Fewest_Calls_In_Flight(t *testing.T) {
+	first, second := &subConnStub{name: "first"}, &subConnStub{name: "second"}
+	pickerBuilder := &leastRequestPickerBuilder{inFlight: map[balancer.SubConn]*int64{}}
+	picker := pickerBuilder.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{first: {}, second: {}}})
+
+	busy, _ := picker.Pick(balancer.PickInfo{})
+	idle, _ := picker.Pick(balancer.PickInfo{})
+	idle.Done(balancer.DoneInfo{})
+	next, _ := picker.Pick(balancer.PickInfo{})
+
+	assert.NotEqual(t, busy.SubConn, idle.SubConn)
+	assert.Equal(t, idle.SubConn, next.SubConn)
+}
+
+func Test_LeastRequestPicker_Should_Keep_Calls_In_Flight_When_Rebuilt(t *testing.T) {
+	first, second := &subConnStub{name: "first"}, &subConnStub{name: "second"}
+	pickerBuilder := &leastRequestPickerBuilder{inFlight: map[balancer.SubConn]*int64{}}
+	picker := pickerBuilder.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{first: {}}})
+	busy, _ := picker.Pick(balancer.PickInfo{})
+
+	picker = pickerBuilder.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{first: {}, second: {}}})
+	for i := 0; i < 4; i++ {
+		result, _ := picker.Pick(balancer.PickInfo{})
+		result.Done(balancer.DoneInfo{})
+
+		assert.Equal(t, second, result.SubConn)
+	}
+	assert.Equal(t, first, busy.SubConn)
+}
+
+func Test_InventoryTarget_Should_Resolve_A_List_Statically(t *testing.T) {
+	t.Setenv("INVENTORY_MS_URI", "inventory-1:50051,inventory-2:50051")
+
+	target, options := inventoryTarget()
+
+	assert.Equal(t, "inventory-static:///inventory-1:50051,inventory-2:50051", target)
+	assert.Len(t, options, 1)
+}
+
+func Test_InventoryTarget_Should_Leave_Other_Schemes_To_gRPC(t *testing.T) {
+	t.Setenv("INVENTORY_MS_URI", "dns:///inventory:50051")
+
+	target, options := inventoryTarget()
+
+	assert.Equal(t, "dns:///inventory:50051", target)
+	assert.Empty(t, options)
+}
+
+func Test_NewInventoryConnection_Should_Refuse_Unknown_Policy(t *testing.T) {
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	t.Setenv("INVENTORY_MS_LB_POLICY", "random")
+
+	_, err := NewInventoryConnection(logger.NewLoggerSpy())
+
+	assert.EqualError(t, err, `unknown inventory load balancing policy "random"`)
+}
+
+func Test_NewInventoryConnection_Should_Refuse_Malformed_Address_In_A_List(t *testing.T) {
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	t.Setenv("INVENTORY_MS_URI", "inventory-1,127.0.0.1:50051")
+
+	_, err := NewInventoryConnection(logger.NewLoggerSpy())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,373 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/infra/grpc_clients/proto"
+	"webapi/pkg/infra/logger"
+	"webapi/pkg/infra/telemetry"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type inventoryClientToTest struct {
+	client     interfaces.IIventoryClient
+	conn       *grpc.ClientConn
+	listener   *countingListener
+	lastUpdate *proto.UpdateProductRequest
+
+	// unavailable makes the server answer Unavailable that many times before
+	// serving, and delay holds every answer back.
+	unavailable int32
+	delay       time.Duration
+	calls       int32
+}
+
+// newInventoryClientToTest serves inventoryServerSpy over an in-memory listener
+// and points a connection from NewInventoryConnection at it.
+func newInventoryClientToTest(t *testing.T) *inventoryClientToTest {
+	listener := &countingListener{Listener: bufconn.Listen(1024 * 1024)}
+	sut := &inventoryClientToTest{listener: listener}
+
+	server := grpc.NewServer()
+	proto.RegisterInventoryServer(server, &inventoryServerSpy{sut: sut})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	t.Setenv("INVENTORY_MS_URI", "bufnet")
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	conn, err := NewInventoryConnection(
+		logger.NewLoggerSpy(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.Listener.(*bufconn.Listener).DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	sut.conn = conn
+	sut.client = NewInventoryClient(logger.NewLoggerSpy(), telemetrySpy{}, conn)
+	return sut
+}
+
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (pst *countingListener) Accept() (net.Conn, error) {
+	conn, err := pst.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&pst.accepted, 1)
+	}
+
+	return conn, err
+}
+
+func (pst *countingListener) Accepted() int {
+	return int(atomic.LoadInt32(&pst.accepted))
+}
+
+type inventoryServerSpy struct {
+	proto.UnimplementedInventoryServer
+	sut *inventoryClientToTest
+}
+
+func (pst *inventoryServerSpy) answer() error {
+	atomic.AddInt32(&pst.sut.calls, 1)
+	time.Sleep(pst.sut.delay)
+
+	if atomic.AddInt32(&pst.sut.unavailable, -1) >= 0 {
+		return status.Error(codes.Unavailable, "restarting")
+	}
+
+	return nil
+}
+
+func (pst *inventoryServerSpy) GetProductById(ctx context.Context, request *proto.GetByIdRequest) (*proto.ProductResponse, error) {
+	if err := pst.answer(); err != nil {
+		return nil, err
+	}
+
+	if request.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "Not Found")
+	}
+
+	return &proto.ProductResponse{Id: request.Id, Title: "Title"}, nil
+}
+
+func (pst *inventoryServerSpy) GetProducts(ctx context.Context, request *proto.GetProductsRequest) (*proto.ProductsResponse, error) {
+	return &proto.ProductsResponse{Value: []*proto.ProductResponse{{Id: "1"}}, Total: 21}, nil
+}
+
+func (pst *inventoryServerSpy) CreateProduct(ctx context.Context, request *proto.CreateProductRequest) (*proto.ProductResponse, error) {
+	if err := pst.answer(); err != nil {
+		return nil, err
+	}
+
+	return &proto.ProductResponse{Id: "1", Title: request.Title}, nil
+}
+
+func (pst *inventoryServerSpy) UpdateProduct(ctx context.Context, request *proto.UpdateProductRequest) (*proto.ProductResponse, error) {
+	pst.sut.lastUpdate = request
+	return &proto.ProductResponse{Id: request.Id}, nil
+}
+
+type telemetrySpy struct{}
+
+func (telemetrySpy) GinMiddle() gin.HandlerFunc {
+	return func(ctx *gin.Context) {}
+}
+func (telemetrySpy) InstrumentQuery(ctx context.Context, sqlType string, sql string) opentracing.Span {
+	return opentracing.StartSpan("")
+}
+func (telemetrySpy) InstrumentGRPCClient(ctx context.Context, clientName string) (opentracing.Span, context.Context) {
+	return opentracing.StartSpan(clientName), ctx
+}
+func (telemetrySpy) InstrumentAMQPPublisher(ctx context.Context, exchangeName, queueName string) (opentracing.Span, context.Context) {
+	return nil, nil
+}
+func (telemetrySpy) StartSpanFromRequest(header http.Header) opentracing.Span {
+	return opentracing.StartSpan("")
+}
+func (telemetrySpy) Inject(span opentracing.Span, request *http.Request) error {
+	return nil
+}
+func (telemetrySpy) InjectAMQPHeader(header map[string]interface{}, ctx context.Context) error {
+	return nil
+}
+func (telemetrySpy) Extract(header http.Header) (opentracing.SpanContext, error) {
+	return nil, nil
+}
+func (telemetrySpy) Dispatch() {}
+func (telemetrySpy) GetTracer() opentracing.Tracer {
+	return nil
+}
+
+var _ telemetry.ITelemetry = telemetrySpy{}
+
+type certificateAuthorityToTest struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCertificateAuthorityToTest(t *testing.T, name string) certificateAuthorityToTest {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	return certificateAuthorityToTest{cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue signs a leaf certificate for name, usable by a server or a client,
+// and returns it with its key as PEM.
+func (pst certificateAuthorityToTest) issue(t *testing.T, name string) ([]byte, []byte) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, pst.cert, &key.PublicKey, pst.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// newTlsInventoryServerToTest serves inventoryServerSpy over TLS on a local
+// port with a certificate for "inventory.local". When clientCA is set, clients
+// must present a certificate it signed.
+func newTlsInventoryServerToTest(t *testing.T, serverCA certificateAuthorityToTest, clientCA *certificateAuthorityToTest) string {
+	certPem, keyPem := serverCA.issue(t, "inventory.local")
+	pair, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &tls.Config{Certificates: []tls.Certificate{pair}}
+	if clientCA != nil {
+		pool := x509.NewCertPool()
+		pool.AddCert(clientCA.cert)
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	proto.RegisterInventoryServer(server, &inventoryServerSpy{sut: &inventoryClientToTest{}})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+// inventoryReplicaToTest is one inventory instance of a pool served over
+// plaintext TCP, with the standard health service reporting SERVING.
+type inventoryReplicaToTest struct {
+	addr   string
+	server *grpc.Server
+	health *health.Server
+	spy    *inventoryClientToTest
+}
+
+func (pst inventoryReplicaToTest) served() int {
+	return int(atomic.LoadInt32(&pst.spy.calls))
+}
+
+func (pst inventoryReplicaToTest) setServing(serving bool) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	pst.health.SetServingStatus("", status)
+}
+
+func newInventoryReplicasToTest(t *testing.T, count int) []inventoryReplicaToTest {
+	replicas := []inventoryReplicaToTest{}
+	for i := 0; i < count; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		replica := inventoryReplicaToTest{
+			addr:   listener.Addr().String(),
+			server: grpc.NewServer(),
+			health: health.NewServer(),
+			spy:    &inventoryClientToTest{},
+		}
+		proto.RegisterInventoryServer(replica.server, &inventoryServerSpy{sut: replica.spy})
+		healthpb.RegisterHealthServer(replica.server, replica.health)
+		go replica.server.Serve(listener)
+		t.Cleanup(replica.server.Stop)
+
+		replicas = append(replicas, replica)
+	}
+
+	return replicas
+}
+
+// newPooledInventoryClientToTest points a connection from
+// NewInventoryConnection at every replica using policy.
+func newPooledInventoryClientToTest(t *testing.T, policy string, replicas []inventoryReplicaToTest) interfaces.IIventoryClient {
+	addresses := []string{}
+	for _, replica := range replicas {
+		addresses = append(addresses, replica.addr)
+	}
+
+	t.Setenv("INVENTORY_MS_URI", strings.Join(addresses, ","))
+	t.Setenv("INVENTORY_MS_LB_POLICY", policy)
+	t.Setenv("INVENTORY_MS_TLS", "false")
+	conn, err := NewInventoryConnection(logger.NewLoggerSpy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewInventoryClient(logger.NewLoggerSpy(), telemetrySpy{}, conn)
+}
+
+type subConnStub struct {
+	name string
+}
+
+func (pst *subConnStub) UpdateAddresses([]resolver.Address) {}
+
+func (pst *subConnStub) Connect() {}
+
+func writeFileToTest(t *testing.T, dir, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// inventoryClientStub answers every call with err, counting the calls that
+// got past the breaker.
+type inventoryClientStub struct {
+	err   error
+	calls int
+}
+
+func (pst *inventoryClientStub) GetProductById(ctx context.Context, id string) (dtos.ProductDto, error) {
+	pst.calls++
+	return dtos.ProductDto{Id: id}, pst.err
+}
+
+func (pst *inventoryClientStub) GetProducts(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	pst.calls++
+	return dtos.ProductsPageDto{}, pst.err
+}
+
+func (pst *inventoryClientStub) GetProductsByType(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	pst.calls++
+	return nil, pst.err
+}
+
+func (pst *inventoryClientStub) UpdateProduct(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	pst.calls++
+	return dtos.ProductDto{}, pst.err
+}
+
+func (pst *inventoryClientStub) RegisterProduct(ctx context.Context, product dtos.ProductDto) (dtos.ProductDto, error) {
+	pst.calls++
+	return dtos.ProductDto{}, pst.err
+}
@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"fmt"
+	"time"
+	"webapi/pkg/app/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mapErrorToHttp turns a gRPC status into the application error the HTTP
+// layer answers with. Messages of client errors come from the service, server
+// errors get a fixed message so internals do not leak to the caller.
+func mapErrorToHttp(grpcError error) error {
+	errStatus, _ := status.FromError(grpcError)
+	details, retryAfter := statusDetails(errStatus)
+
+	switch errStatus.Code() {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return errors.BadRequestError{Message: errStatus.Message(), Details: details}
+	case codes.NotFound:
+		return errors.NotFoundError{Message: messageOr(errStatus, "product not found"), Details: details}
+	case codes.AlreadyExists, codes.Aborted:
+		return errors.ConflictError{Message: errStatus.Message(), Details: details}
+	case codes.Unauthenticated, codes.PermissionDenied:
+		// The inventory refused the webapi itself, not the caller, whose
+		// credentials the auth middleware already checked.
+		return errors.InternalError{Message: "inventory service refused the webapi credentials", Details: details}
+	case codes.ResourceExhausted:
+		return errors.TooManyRequestsError{Message: messageOr(errStatus, "inventory service is throttling requests"), RetryAfter: retryAfter, Details: details}
+	case codes.Unavailable:
+		return errors.ServiceUnavailableError{Message: "inventory service unavailable", RetryAfter: retryAfter, Details: details}
+	case codes.DeadlineExceeded:
+		return errors.GatewayTimeoutError{Message: "inventory service did not answer in time", Details: details}
+	default:
+		return errors.InternalError{Message: "some error occur in grpc client request", Details: details}
+	}
+}
+
+func messageOr(errStatus *status.Status, fallback string) string {
+	if errStatus.Message() == "" {
+		return fallback
+	}
+
+	return errStatus.Message()
+}
+
+// statusDetails flattens the error details the service attached to the
+// status. DebugInfo is left out on purpose, it carries stack traces.
+func statusDetails(errStatus *status.Status) ([]string, time.Duration) {
+	var details []string
+	var retryAfter time.Duration
+
+	for _, detail := range errStatus.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				details = append(details, fmt.Sprintf("%s: %s", violation.GetField(), violation.GetDescription()))
+			}
+		case *errdetails.PreconditionFailure:
+			for _, violation := range d.GetViolations() {
+				details = append(details, fmt.Sprintf("%s: %s", violation.GetSubject(), violation.GetDescription()))
+			}
+		case *errdetails.QuotaFailure:
+			for _, violation := range d.GetViolations() {
+				details = append(details, fmt.Sprintf("%s: %s", violation.GetSubject(), violation.GetDescription()))
+			}
+		case *errdetails.ErrorInfo:
+			details = append(details, d.GetReason())
+		case *errdetails.ResourceInfo:
+			details = append(details, fmt.Sprintf("%s %s: %s", d.GetResourceType(), d.GetResourceName(), d.GetDescription()))
+		case *errdetails.LocalizedMessage:
+			details = append(details, d.GetMessage())
+		case *errdetails.RetryInfo:
+			retryAfter = d.GetRetryDelay().AsDuration()
+		}
+	}
+
+	return details, retryAfter
+}
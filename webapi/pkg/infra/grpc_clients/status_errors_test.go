@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"testing"
+	"time"
+	"webapi/pkg/app/errors"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func Test_MapErrorToHttp_Should_Map_Every_Status_Code(t *testing.T) {
+	type inputs struct {
+		code     codes.Code
+		expected error
+	}
+	inputsToTest := []inputs{
+		{code: codes.InvalidArgument, expected: errors.BadRequestError{}},
+		{code: codes.FailedPrecondition, expected: errors.BadRequestError{}},
+		{code: codes.OutOfRange, expected: errors.BadRequestError{}},
+		{code: codes.NotFound, expected: errors.NotFoundError{}},
+		{code: codes.AlreadyExists, expected: errors.ConflictError{}},
+		{code: codes.Aborted, expected: errors.ConflictError{}},
+		{code: codes.Unauthenticated, expected: errors.InternalError{}},
+		{code: codes.PermissionDenied, expected: errors.InternalError{}},
+		{code: codes.ResourceExhausted, expected: errors.TooManyRequestsError{}},
+		{code: codes.Unavailable, expected: errors.ServiceUnavailableError{}},
+		{code: codes.DeadlineExceeded, expected: errors.GatewayTimeoutError{}},
+		{code: codes.Internal, expected: errors.InternalError{}},
+		{code: codes.Unknown, expected: errors.InternalError{}},
+	}
+
+	for _, in := range inputsToTest {
+		err := mapErrorToHttp(status.Error(in.code, "some message"))
+
+		assert.IsType(t, in.expected, err, in.code.String())
+	}
+}
+
+func Test_MapErrorToHttp_Should_Keep_Client_Error_Messages_Only(t *testing.T) {
+	badRequest := mapErrorToHttp(status.Error(codes.InvalidArgument, "amount must be positive"))
+	internal := mapErrorToHttp(status.Error(codes.Internal, "mongo: connection refused"))
+
+	assert.EqualError(t, badRequest, "amount must be positive")
+	assert.EqualError(t, internal, "some error occur in grpc client request")
+}
+
+func Test_MapErrorToHttp_Should_Fallback_NotFound_Message(t *testing.T) {
+	err := mapErrorToHttp(status.Error(codes.NotFound, ""))
+
+	assert.EqualError(t, err, "product not found")
+}
+
+func Test_MapErrorToHttp_Should_Preserve_Field_Violations(t *testing.T) {
+	errStatus, _ := status.New(codes.InvalidArgument, "invalid product").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "title", Description: "must not be empty"},
+			{Field: "amount_in_stock", Description: "must not be negative"},
+		}},
+		&errdetails.DebugInfo{Detail: "stack trace"},
+	)
+
+	err := mapErrorToHttp(errStatus.Err())
+
+	assert.Equal(t, []string{"title: must not be empty", "amount_in_stock: must not be negative"}, err.(errors.BadRequestError).Details)
+}
+
+func Test_MapErrorToHttp_Should_Preserve_Conflict_Details(t *testing.T) {
+	errStatus, _ := status.New(codes.AlreadyExists, "product already exists").WithDetails(
+		&errdetails.ResourceInfo{ResourceType: "product", ResourceName: "tag-1", Description: "tag is taken"},
+		&errdetails.ErrorInfo{Reason: "DUPLICATED_TAG"},
+	)
+
+	err := mapErrorToHttp(errStatus.Err())
+
+	assert.Equal(t, []string{"product tag-1: tag is taken", "DUPLICATED_TAG"}, err.(errors.ConflictError).Details)
+}
+
+func Test_MapErrorToHttp_Should_Read_Retry_Delay(t *testing.T) {
+	exhausted, _ := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second * 5)})
+	unavailable, _ := status.New(codes.Unavailable, "draining").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second * 10)})
+
+	throttled := mapErrorToHttp(exhausted.Err())
+	down := mapErrorToHttp(unavailable.Err())
+
+	assert.Equal(t, time.Second*5, throttled.(errors.TooManyRequestsError).RetryAfter)
+	assert.Equal(t, time.Second*10, down.(errors.ServiceUnavailableError).RetryAfter)
+}
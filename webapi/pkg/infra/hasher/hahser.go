@@ -1,30 +1,84 @@
 package hasher
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 
 	"webapi/pkg/app/errors"
 	"webapi/pkg/app/interfaces"
 )
 
+const argon2idPrefix = "$argon2id$"
+
+const minArgon2KeyLength = 16
+const minArgon2SaltLength = 16
+
+var generateSalt = rand.Read
+var deriveKey = argon2.IDKey
+var compareHash = bcrypt.CompareHashAndPassword
+
+var encoding = base64.RawStdEncoding
+
+// argon2Params are the Argon2id cost settings. They are encoded in every hash
+// so changing them never breaks existing passwords, it only marks them for
+// rehash on the next sign in.
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
 type hasher struct {
 	logger interfaces.ILogger
+	params argon2Params
 }
 
-var generateHash = bcrypt.GenerateFromPassword
-var compareHash = bcrypt.CompareHashAndPassword
-
+// Hahser hashes the text with Argon2id and returns it as a PHC string:
+// $argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<key>
 func (pst hasher) Hahser(text string) (string, error) {
-	hashed, err := generateHash([]byte(text), 9)
-	if err != nil {
+	salt := make([]byte, pst.params.saltLength)
+	if _, err := generateSalt(salt); err != nil {
 		pst.logger.Error(err.Error())
 		return "", errors.NewInternalError(err.Error())
 	}
 
-	return string(hashed), nil
+	key := deriveKey([]byte(text), salt, pst.params.iterations, pst.params.memory, pst.params.parallelism, pst.params.keyLength)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		pst.params.memory,
+		pst.params.iterations,
+		pst.params.parallelism,
+		encoding.EncodeToString(salt),
+		encoding.EncodeToString(key),
+	), nil
 }
 
-func (h hasher) Verify(originalText, hashedText string) bool {
+// Verify picks the algorithm from the stored hash, so bcrypt hashes written
+// before the switch to Argon2id keep working.
+func (pst hasher) Verify(originalText, hashedText string) bool {
+	if strings.HasPrefix(hashedText, argon2idPrefix) {
+		params, salt, key, err := decodeArgon2id(hashedText)
+		if err != nil {
+			return false
+		}
+
+		derived := deriveKey([]byte(originalText), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+		return subtle.ConstantTimeCompare(derived, key) == 1
+	}
+
 	if err := compareHash([]byte(hashedText), []byte(originalText)); err != nil {
 		return false
 	}
@@ -32,8 +86,85 @@ func (h hasher) Verify(originalText, hashedText string) bool {
 	return true
 }
 
+// NeedsRehash reports whether the hash was produced by another algorithm or
+// with other Argon2id parameters than the ones configured now.
+func (pst hasher) NeedsRehash(hashedText string) bool {
+	if !strings.HasPrefix(hashedText, argon2idPrefix) {
+		return true
+	}
+
+	params, _, _, err := decodeArgon2id(hashedText)
+	if err != nil {
+		return true
+	}
+
+	return params != pst.params
+}
+
+func decodeArgon2id(hashedText string) (argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hashedText, "$")
+	if len(parts) != 6 {
+		return argon2Params{}, nil, nil, fmt.Errorf("malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2Params{}, nil, nil, err
+	}
+
+	if version != argon2.Version {
+		return argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	params := argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return argon2Params{}, nil, nil, err
+	}
+
+	salt, err := encoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, err
+	}
+
+	key, err := encoding.DecodeString(parts[5])
+	if err != nil {
+		return argon2Params{}, nil, nil, err
+	}
+
+	if params.memory == 0 || params.iterations == 0 || params.parallelism == 0 || len(key) < minArgon2KeyLength {
+		return argon2Params{}, nil, nil, fmt.Errorf("malformed argon2id hash")
+	}
+
+	params.saltLength = uint32(len(salt))
+	params.keyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func argon2ParamsFromEnv() argon2Params {
+	return argon2Params{
+		memory:      uint32(uintFromEnv("HASHER_ARGON2_MEMORY", 64*1024, 1, 32)),
+		iterations:  uint32(uintFromEnv("HASHER_ARGON2_ITERATIONS", 3, 1, 32)),
+		parallelism: uint8(uintFromEnv("HASHER_ARGON2_PARALLELISM", 2, 1, 8)),
+		saltLength:  uint32(uintFromEnv("HASHER_ARGON2_SALT_LENGTH", 16, minArgon2SaltLength, 32)),
+		keyLength:   uint32(uintFromEnv("HASHER_ARGON2_KEY_LENGTH", 32, minArgon2KeyLength, 32)),
+	}
+}
+
+// uintFromEnv falls back when the value is missing, below minimum or does not
+// fit in bitSize bits.
+func uintFromEnv(name string, fallback, minimum uint64, bitSize int) uint64 {
+	value, err := strconv.ParseUint(os.Getenv(name), 10, bitSize)
+	if err != nil || value < minimum {
+		return fallback
+	}
+
+	return value
+}
+
 func NewHahser(logger interfaces.ILogger) interfaces.IHasher {
 	return &hasher{
 		logger,
+		argon2ParamsFromEnv(),
 	}
 }
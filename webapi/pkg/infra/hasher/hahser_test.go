@@ -1,28 +1,38 @@
 package hasher
 
 import (
+	"crypto/rand"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"webapi/pkg/app/errors"
 )
 
 func Test_Should_Execute_Hahser_Correctly(t *testing.T) {
-	var bcryptMock = newBcryptMock(false, []byte("hashed"))
-	generateHash = bcryptMock.GenerateHash
-
 	sut := newHasherToTest()
 
 	hashed, err := sut.hasher.Hahser("text")
 
 	assert.Equal(t, err, nil)
-	assert.Equal(t, hashed, "hashed")
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.True(t, sut.hasher.Verify("text", hashed))
+}
+
+func Test_Should_Use_A_New_Salt_For_Every_Hash(t *testing.T) {
+	sut := newHasherToTest()
+
+	first, _ := sut.hasher.Hahser("text")
+	second, _ := sut.hasher.Hahser("text")
+
+	assert.NotEqual(t, first, second)
 }
 
 func Test_Should_Execute_Hahser_When_Same_Error_Occur_In_Crypto(t *testing.T) {
-	var bcryptMock = newBcryptMock(true, []byte("hash"))
-	generateHash = bcryptMock.GenerateHash
+	generateSalt = failingSalt
+	defer func() { generateSalt = rand.Read }()
 
 	sut := newHasherToTest()
 
@@ -32,9 +42,38 @@ func Test_Should_Execute_Hahser_When_Same_Error_Occur_In_Crypto(t *testing.T) {
 	assert.IsType(t, err, errors.InternalError{})
 }
 
+func Test_Should_Retorne_False_If_Argon2id_Hash_Is_Wrong(t *testing.T) {
+	sut := newHasherToTest()
+	hashed, _ := sut.hasher.Hahser("text")
+
+	assert.False(t, sut.hasher.Verify("other text", hashed))
+}
+
+func Test_Should_Retorne_False_If_Argon2id_Hash_Is_Malformed(t *testing.T) {
+	sut := newHasherToTest()
+
+	assert.False(t, sut.hasher.Verify("text", "$argon2id$v=19$m=1024,t=1,p=1$not base64!$"))
+	assert.False(t, sut.hasher.Verify("text", "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5"))
+}
+
+func Test_Should_Refuse_Argon2id_Hash_With_Zero_Cost_Or_Short_Key(t *testing.T) {
+	sut := newHasherToTest()
+	hashed, _ := sut.hasher.Hahser("text")
+	parts := strings.Split(hashed, "$")
+
+	for _, params := range []string{"m=0,t=1,p=1", "m=1024,t=0,p=1", "m=1024,t=1,p=0"} {
+		_, _, _, err := decodeArgon2id(strings.Join([]string{"", parts[1], parts[2], params, parts[4], parts[5]}, "$"))
+		assert.Error(t, err)
+	}
+
+	_, _, _, err := decodeArgon2id(strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], parts[5][:20]}, "$"))
+	assert.Error(t, err)
+}
+
 func Test_Should_Return_Ture_If_Hash_Is_Correctly(t *testing.T) {
-	var bcryptMock = newBcryptMock(false, []byte(""))
+	var bcryptMock = newBcryptMock(false)
 	compareHash = bcryptMock.CompareHash
+	defer func() { compareHash = bcrypt.CompareHashAndPassword }()
 
 	sut := newHasherToTest()
 
@@ -44,8 +83,9 @@ func Test_Should_Return_Ture_If_Hash_Is_Correctly(t *testing.T) {
 }
 
 func Test_Should_Retorne_False_If_Hash_Is_Wrong(t *testing.T) {
-	var bcryptMock = newBcryptMock(true, []byte(""))
+	var bcryptMock = newBcryptMock(true)
 	compareHash = bcryptMock.CompareHash
+	defer func() { compareHash = bcrypt.CompareHashAndPassword }()
 
 	sut := newHasherToTest()
 
@@ -53,3 +93,59 @@ func Test_Should_Retorne_False_If_Hash_Is_Wrong(t *testing.T) {
 
 	assert.False(t, result)
 }
+
+func Test_Should_Verify_Legacy_Bcrypt_Hashes(t *testing.T) {
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("text"), bcrypt.MinCost)
+	sut := newHasherToTest()
+
+	assert.True(t, sut.hasher.Verify("text", string(legacy)))
+	assert.False(t, sut.hasher.Verify("other text", string(legacy)))
+}
+
+func Test_Should_Need_Rehash_For_Bcrypt_Hashes(t *testing.T) {
+	legacy, _ := bcrypt.GenerateFromPassword([]byte("text"), bcrypt.MinCost)
+	sut := newHasherToTest()
+
+	assert.True(t, sut.hasher.NeedsRehash(string(legacy)))
+}
+
+func Test_Should_Need_Rehash_When_Parameters_Changed(t *testing.T) {
+	sut := newHasherToTest()
+	hashed, _ := sut.hasher.Hahser("text")
+
+	stronger := &hasher{sut.loggerSpy, argon2Params{memory: 2048, iterations: 1, parallelism: 1, saltLength: 16, keyLength: 32}}
+
+	assert.False(t, sut.hasher.NeedsRehash(hashed))
+	assert.True(t, stronger.NeedsRehash(hashed))
+	assert.True(t, stronger.Verify("text", hashed))
+}
+
+func Test_Should_Read_Argon2_Parameters_From_Env(t *testing.T) {
+	t.Setenv("HASHER_ARGON2_MEMORY", "2048")
+	t.Setenv("HASHER_ARGON2_ITERATIONS", "")
+	t.Setenv("HASHER_ARGON2_PARALLELISM", "4")
+	t.Setenv("HASHER_ARGON2_SALT_LENGTH", "abc")
+	t.Setenv("HASHER_ARGON2_KEY_LENGTH", "")
+
+	params := argon2ParamsFromEnv()
+
+	assert.Equal(t, argon2Params{memory: 2048, iterations: 3, parallelism: 4, saltLength: 16, keyLength: 32}, params)
+}
+
+func Test_Should_Not_Wrap_Argon2_Parallelism_Above_255(t *testing.T) {
+	t.Setenv("HASHER_ARGON2_PARALLELISM", "258")
+
+	params := argon2ParamsFromEnv()
+
+	assert.Equal(t, uint8(2), params.parallelism)
+}
+
+func Test_Should_Refuse_Argon2_Salt_And_Key_Shorter_Than_16_Bytes(t *testing.T) {
+	t.Setenv("HASHER_ARGON2_SALT_LENGTH", "8")
+	t.Setenv("HASHER_ARGON2_KEY_LENGTH", "15")
+
+	params := argon2ParamsFromEnv()
+
+	assert.Equal(t, uint32(16), params.saltLength)
+	assert.Equal(t, uint32(32), params.keyLength)
+}
@@ -8,15 +8,6 @@ import (
 
 type bcryptMocked struct {
 	failure bool
-	hash    []byte
-}
-
-func (m bcryptMocked) GenerateHash(password []byte, cost int) ([]byte, error) {
-	if m.failure {
-		return []byte(""), errors.New("bcrypt error")
-	}
-
-	return m.hash, nil
 }
 
 func (m bcryptMocked) CompareHash(hashedPassword, password []byte) error {
@@ -27,13 +18,25 @@ func (m bcryptMocked) CompareHash(hashedPassword, password []byte) error {
 	return nil
 }
 
-func newBcryptMock(failure bool, hash []byte) *bcryptMocked {
+func newBcryptMock(failure bool) *bcryptMocked {
 	return &bcryptMocked{
 		failure: failure,
-		hash:    hash,
 	}
 }
 
+func failingSalt(b []byte) (int, error) {
+	return 0, errors.New("rand error")
+}
+
+// cheapParams keeps Argon2id fast enough for unit tests.
+var cheapParams = argon2Params{
+	memory:      1024,
+	iterations:  1,
+	parallelism: 1,
+	saltLength:  16,
+	keyLength:   32,
+}
+
 type hasherToTest struct {
 	hasher    interfaces.IHasher
 	loggerSpy interfaces.ILogger
@@ -43,7 +46,7 @@ func newHasherToTest() hasherToTest {
 	loggerSpy := logger.NewLoggerSpy()
 
 	return hasherToTest{
-		hasher:    NewHahser(loggerSpy),
+		hasher:    &hasher{loggerSpy, cheapParams},
 		loggerSpy: loggerSpy,
 	}
 }
@@ -1,9 +1,16 @@
 package httpserver
 
 import (
+	"context"
 	"errors"
+	"expvar"
 	"fmt"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/interfaces/http"
@@ -11,6 +18,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout is how long in-flight requests get to finish once the
+// process is asked to stop.
+const shutdownTimeout = time.Second * 10
+
 type IHttpServer interface {
 	Setup()
 	RegistreRoute(method http.HttpMethod, path string, handlers ...gin.HandlerFunc) error
@@ -27,7 +38,33 @@ var httpServerWrapper = gin.New
 
 func (pst *HttpServer) Setup() {
 	pst.server = httpServerWrapper()
+	if err := pst.server.SetTrustedProxies(trustedProxies()); err != nil {
+		panic(err)
+	}
 	pst.server.Use(pst.logger.GetHandleFunc())
+
+	// expvar counters, such as the user cache hits and misses. nginx keeps
+	// this path off the public listener.
+	pst.server.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+}
+
+// trustedProxies lists the networks allowed to set X-Forwarded-For. Anything
+// else could spoof the client IP that sign in throttling relies on, so only
+// the local nginx is trusted unless TRUSTED_PROXIES says otherwise.
+func trustedProxies() []string {
+	value := os.Getenv("TRUSTED_PROXIES")
+	if value == "" {
+		return []string{"127.0.0.1/32", "::1/128"}
+	}
+
+	proxies := []string{}
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
 }
 
 func (pst HttpServer) RegistreRoute(method http.HttpMethod, path string, handlers ...gin.HandlerFunc) error {
@@ -53,11 +90,34 @@ func (pst HttpServer) RegisterMiddleware(middleware ...gin.HandlerFunc) {
 	pst.server.Use(middleware...)
 }
 
+// Run serves until SIGINT or SIGTERM, then drains in-flight requests and
+// returns so the caller can release what the handlers were using.
 func (pst HttpServer) Run() error {
-	host := os.Getenv("HOST")
-	port := os.Getenv("PORT")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &nethttp.Server{
+		Addr:    fmt.Sprintf("%s:%s", os.Getenv("HOST"), os.Getenv("PORT")),
+		Handler: pst.server,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	pst.logger.Info("[HttpServer::Run] shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
-	return pst.server.Run(fmt.Sprintf("%s:%s", host, port))
+	return server.Shutdown(shutdownCtx)
 }
 
 func NewHttpServer(logger interfaces.ILogger) IHttpServer {
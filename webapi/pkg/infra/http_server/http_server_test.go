@@ -2,10 +2,11 @@ package httpserver
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +21,16 @@ func Test_Should_Execute_Setup_Correctly(t *testing.T) {
 	assert.Equal(t, calledTimes, 1, "Should called GetHandleFunc once")
 }
 
+func Test_Should_Expose_Expvar_Metrics(t *testing.T) {
+	sut := newHttpServerToTest()
+	sut.server.Setup()
+
+	response, err := sut.doRequest("GET", "/debug/vars")
+
+	assert.NoError(t, err, "Error should be nil")
+	assert.Equal(t, response.StatusCode, http.StatusOK)
+}
+
 func Test_Should_Execute_RegistreRoute_POST_Correctly(t *testing.T) {
 	sut := newHttpServerToTest()
 	sut.server.Setup()
@@ -91,11 +102,47 @@ func Test_Should_Execute_Run_Correctly(t *testing.T) {
 	sut := newHttpServerToTest()
 	sut.server.Setup()
 
-	go func(t *testing.T) {
-		err := sut.server.Run()
+	stop := sut.run(t)
+
+	assert.NoError(t, stop(), "Server should stop without error")
+}
+
+func Test_Should_Trust_Only_Local_Proxies_By_Default(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "")
+
+	assert.Equal(t, []string{"127.0.0.1/32", "::1/128"}, trustedProxies())
+}
+
+func Test_Should_Read_Trusted_Proxies_From_Env(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 172.16.0.0/12")
+
+	assert.Equal(t, []string{"10.0.0.0/8", "172.16.0.0/12"}, trustedProxies())
+}
+
+func Test_Should_Take_The_Client_Ip_From_A_Trusted_Proxy_When_Running(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "")
+	sut := newHttpServerToTest()
+	sut.server.Setup()
+	sut.server.RegistreRoute("GET", "/ip", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, ctx.ClientIP())
+	})
+
+	stop := sut.run(t)
+	defer stop()
+
+	request, _ := http.NewRequest("GET", fmt.Sprintf("http://%s:%s/ip", os.Getenv("HOST"), os.Getenv("PORT")), nil)
+	request.Header.Set("X-Forwarded-For", "203.0.113.7")
+	response, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
 
-		assert.NoError(t, err, "Server should run without panic")
-	}(t)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, "203.0.113.7", string(body))
+}
+
+func Test_Should_Refuse_Invalid_Trusted_Proxies(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "not a network")
+	sut := newHttpServerToTest()
 
-	time.Sleep(time.Microsecond * 1)
+	assert.Panics(t, sut.server.Setup)
 }
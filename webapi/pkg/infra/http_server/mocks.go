@@ -1,8 +1,13 @@
 package httpserver
 
 import (
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/infra/logger"
 
@@ -26,6 +31,44 @@ func (pst httpServerToTest) doRequest(method, path string) (*http.Response, erro
 	return w.Result(), nil
 }
 
+// run starts Run on a free local port, set in HOST and PORT, and waits until it
+// answers. stop sends SIGTERM, which Run handles, and returns what Run did.
+func (pst httpServerToTest) run(t *testing.T) (stop func() error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	t.Setenv("HOST", "127.0.0.1")
+	t.Setenv("PORT", fmt.Sprint(port))
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- pst.server.Run()
+	}()
+
+	for i := 0; ; i++ {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if i == 100 {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return func() error {
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+			return err
+		}
+		return <-stopped
+	}
+}
+
 func newHttpServerToTest() httpServerToTest {
 	gin.SetMode(gin.TestMode)
 
@@ -20,11 +20,21 @@ func (l *loggerSpy) GetHandleFunc() gin.HandlerFunc {
 	l.GetHandleFuncCallerCount++
 	return func(*gin.Context) {}
 }
-func (l *loggerSpy) Debug(msg string, fields ...zap.Field) {}
-func (l *loggerSpy) Info(msg string, fields ...zap.Field)  {}
-func (l *loggerSpy) Warn(msg string, fields ...zap.Field)  {}
-func (l *loggerSpy) Error(msg string, fields ...zap.Field) {}
-func (l *loggerSpy) Fatal(msg string, fields ...zap.Field) {}
+func (l *loggerSpy) Debug(msg string, fields ...zap.Field) {
+	l.DebugCallerCount++
+}
+func (l *loggerSpy) Info(msg string, fields ...zap.Field) {
+	l.InfoCallerCount++
+}
+func (l *loggerSpy) Warn(msg string, fields ...zap.Field) {
+	l.WarnCallerCount++
+}
+func (l *loggerSpy) Error(msg string, fields ...zap.Field) {
+	l.ErrorCallerCount++
+}
+func (l *loggerSpy) Fatal(msg string, fields ...zap.Field) {
+	l.FatalCallerCount++
+}
 
 func NewLoggerSpy() interfaces.ILogger {
 	return &loggerSpy{
@@ -0,0 +1,108 @@
+package login_attempt_store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+)
+
+var now = time.Now
+
+// memoryStore keeps login attempts in process memory. It is the default for a
+// single instance; deployments with several replicas should use the Postgres
+// store so lockouts are shared between them.
+type memoryStore struct {
+	mu        sync.Mutex
+	attempts  map[string]entities.LoginAttempt
+	lastSweep time.Time
+}
+
+func (pst *memoryStore) Find(ctx context.Context, key string) (*entities.LoginAttempt, error) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	attempt, ok := pst.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &attempt, nil
+}
+
+func (pst *memoryStore) RegisterFailure(ctx context.Context, key string, window time.Duration) (entities.LoginAttempt, error) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	current := now()
+	pst.sweep(current, window)
+
+	attempt, ok := pst.attempts[key]
+	if !ok || isStale(attempt, current, window) {
+		attempt = entities.LoginAttempt{Key: key}
+	}
+
+	attempt.Failures++
+	attempt.LastFailureAt = current
+	pst.attempts[key] = attempt
+
+	return attempt, nil
+}
+
+func (pst *memoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	attempt, ok := pst.attempts[key]
+	if !ok {
+		attempt = entities.LoginAttempt{Key: key, LastFailureAt: now()}
+	}
+
+	attempt.LockedUntil = &until
+	pst.attempts[key] = attempt
+
+	return nil
+}
+
+func (pst *memoryStore) Reset(ctx context.Context, key string) error {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	delete(pst.attempts, key)
+	return nil
+}
+
+// sweep drops stale entries at most once per window so the map cannot grow
+// without bound under a spraying attack.
+func (pst *memoryStore) sweep(current time.Time, window time.Duration) {
+	if current.Sub(pst.lastSweep) < window {
+		return
+	}
+
+	for key, attempt := range pst.attempts {
+		if isStale(attempt, current, window) {
+			delete(pst.attempts, key)
+		}
+	}
+
+	pst.lastSweep = current
+}
+
+// isStale reports whether the attempt has been quiet for a whole window, counting
+// from the end of its lockout when there is one.
+func isStale(attempt entities.LoginAttempt, current time.Time, window time.Duration) bool {
+	lastActivity := attempt.LastFailureAt
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(lastActivity) {
+		lastActivity = *attempt.LockedUntil
+	}
+
+	return current.Sub(lastActivity) > window
+}
+
+func NewMemoryLoginAttemptStore() interfaces.ILoginAttemptStore {
+	return &memoryStore{
+		attempts: map[string]entities.LoginAttempt{},
+	}
+}
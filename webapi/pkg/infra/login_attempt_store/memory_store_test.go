@@ -0,0 +1,69 @@
+package login_attempt_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStore_Should_Count_Failures_Within_Window(t *testing.T) {
+	sut := NewMemoryLoginAttemptStore()
+
+	sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+	attempt, err := sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempt.Failures)
+}
+
+func Test_MemoryStore_Should_Restart_Count_After_Window(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	sut := NewMemoryLoginAttemptStore()
+
+	sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+	current = current.Add(time.Minute * 2)
+	attempt, _ := sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+
+	assert.Equal(t, 1, attempt.Failures)
+}
+
+func Test_MemoryStore_Should_Keep_Count_Until_Window_After_Lockout(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+	sut := NewMemoryLoginAttemptStore()
+
+	sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+	sut.Lock(context.Background(), "account:some@email.com", current.Add(time.Hour))
+	current = current.Add(time.Hour + time.Second*30)
+	attempt, _ := sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+
+	assert.Equal(t, 2, attempt.Failures)
+}
+
+func Test_MemoryStore_Should_Find_Lockout(t *testing.T) {
+	sut := NewMemoryLoginAttemptStore()
+	until := time.Now().Add(time.Minute)
+
+	sut.RegisterFailure(context.Background(), "ip:127.0.0.1", time.Minute)
+	sut.Lock(context.Background(), "ip:127.0.0.1", until)
+	attempt, err := sut.Find(context.Background(), "ip:127.0.0.1")
+
+	assert.NoError(t, err)
+	assert.True(t, attempt.IsLocked(time.Now()))
+}
+
+func Test_MemoryStore_Should_Forget_Key_On_Reset(t *testing.T) {
+	sut := NewMemoryLoginAttemptStore()
+
+	sut.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+	sut.Reset(context.Background(), "account:some@email.com")
+	attempt, err := sut.Find(context.Background(), "account:some@email.com")
+
+	assert.NoError(t, err)
+	assert.Nil(t, attempt)
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"webapi/pkg/app/interfaces"
+)
+
+// cachedTokenDenylistRepository remembers the tokens found not revoked, never
+// past their own expiry. Add drops the token on every instance sharing the
+// cache invalidations.
+type cachedTokenDenylistRepository struct {
+	interfaces.ITokenDenylistRepository
+	cache versionedCache
+}
+
+func (pst cachedTokenDenylistRepository) Add(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	defer pst.cache.invalidate(ctx, revokedTokenCacheKey(tokenId))
+	return pst.ITokenDenylistRepository.Add(ctx, tokenId, expiresAt)
+}
+
+func (pst cachedTokenDenylistRepository) Contains(ctx context.Context, tokenId string, expiresAt time.Time) (bool, error) {
+	key := pst.cache.key(ctx, revokedTokenCacheKey(tokenId))
+	if cached, ok := pst.cache.Get(ctx, key); ok {
+		if until, err := strconv.ParseInt(string(cached), 10, 64); err == nil && time.Now().Before(time.Unix(until, 0)) {
+			return false, nil
+		}
+	}
+
+	revoked, err := pst.ITokenDenylistRepository.Contains(ctx, tokenId, expiresAt)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	pst.cache.Set(ctx, key, []byte(strconv.FormatInt(expiresAt.Unix(), 10)))
+	return false, nil
+}
+
+func revokedTokenCacheKey(tokenId string) string {
+	return "revoked_token:" + tokenId
+}
+
+// NewCachedTokenDenylistRepository returns repository unchanged when cache is
+// nil.
+func NewCachedTokenDenylistRepository(repository interfaces.ITokenDenylistRepository, cache interfaces.ICache) interfaces.ITokenDenylistRepository {
+	if cache == nil {
+		return repository
+	}
+
+	return cachedTokenDenylistRepository{
+		repository,
+		versionedCache{cache},
+	}
+}
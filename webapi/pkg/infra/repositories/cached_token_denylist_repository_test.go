@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+	"webapi/pkg/infra/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CachedTokenDenylistRepository_Should_Remember_Tokens_Not_Revoked(t *testing.T) {
+	lookups := 0
+	sut := NewCachedTokenDenylistRepository(tokenDenylistRepositorySpy{lookups: &lookups}, cache.NewMemoryCache("denylist_test", 10, time.Minute))
+	expiresAt := time.Now().Add(time.Hour)
+
+	sut.Contains(context.Background(), "token id", expiresAt)
+	revoked, err := sut.Contains(context.Background(), "token id", expiresAt)
+
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	assert.Equal(t, 1, lookups)
+}
+
+func Test_CachedTokenDenylistRepository_Should_Not_Remember_Past_The_Token_Expiry(t *testing.T) {
+	lookups := 0
+	sut := NewCachedTokenDenylistRepository(tokenDenylistRepositorySpy{lookups: &lookups}, cache.NewMemoryCache("denylist_test_expiry", 10, time.Minute))
+	expiresAt := time.Now().Add(-time.Second)
+
+	sut.Contains(context.Background(), "token id", expiresAt)
+	sut.Contains(context.Background(), "token id", expiresAt)
+
+	assert.Equal(t, 2, lookups)
+}
+
+func Test_CachedTokenDenylistRepository_Should_Not_Remember_Revoked_Tokens(t *testing.T) {
+	lookups := 0
+	sut := NewCachedTokenDenylistRepository(tokenDenylistRepositorySpy{lookups: &lookups, revoked: true}, cache.NewMemoryCache("denylist_test_revoked", 10, time.Minute))
+	expiresAt := time.Now().Add(time.Hour)
+
+	sut.Contains(context.Background(), "token id", expiresAt)
+	revoked, _ := sut.Contains(context.Background(), "token id", expiresAt)
+
+	assert.True(t, revoked)
+	assert.Equal(t, 2, lookups)
+}
+
+func Test_CachedTokenDenylistRepository_Should_Forget_A_Token_Once_Revoked(t *testing.T) {
+	lookups := 0
+	sut := NewCachedTokenDenylistRepository(tokenDenylistRepositorySpy{lookups: &lookups}, cache.NewMemoryCache("denylist_test_add", 10, time.Minute))
+	expiresAt := time.Now().Add(time.Hour)
+
+	sut.Contains(context.Background(), "token id", expiresAt)
+	sut.Add(context.Background(), "token id", expiresAt)
+	sut.Contains(context.Background(), "token id", expiresAt)
+
+	assert.Equal(t, 2, lookups)
+}
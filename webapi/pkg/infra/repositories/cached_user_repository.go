@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+)
+
+// cachedUserRepository keeps recently read users so token validation does not
+// query the database on every authenticated request. Writes going through it
+// drop the user on every instance sharing the cache invalidations.
+type cachedUserRepository struct {
+	interfaces.IUserRepository
+	cache versionedCache
+}
+
+func (pst cachedUserRepository) FindById(ctx context.Context, id int) (*entities.User, error) {
+	key := pst.cache.key(ctx, userCacheKey(id))
+	if cached, ok := pst.cache.Get(ctx, key); ok {
+		user := entities.User{}
+		if json.Unmarshal(cached, &user) == nil {
+			return &user, nil
+		}
+	}
+
+	user, err := pst.IUserRepository.FindById(ctx, id)
+	if err != nil || user == nil {
+		return user, err
+	}
+
+	if encoded, err := json.Marshal(user); err == nil {
+		pst.cache.Set(ctx, key, encoded)
+	}
+	return user, nil
+}
+
+func (pst cachedUserRepository) UpdatePassword(ctx context.Context, id int, password string) error {
+	defer pst.cache.invalidate(ctx, userCacheKey(id))
+	return pst.IUserRepository.UpdatePassword(ctx, id, password)
+}
+
+func (pst cachedUserRepository) MarkEmailAsVerified(ctx context.Context, id int) error {
+	defer pst.cache.invalidate(ctx, userCacheKey(id))
+	return pst.IUserRepository.MarkEmailAsVerified(ctx, id)
+}
+
+func (pst cachedUserRepository) Update(ctx context.Context, id int, dto dtos.UpdateUserDto) (*entities.User, error) {
+	defer pst.cache.invalidate(ctx, userCacheKey(id))
+	return pst.IUserRepository.Update(ctx, id, dto)
+}
+
+// SoftDelete evicts the user so their tokens are rejected right away.
+func (pst cachedUserRepository) SoftDelete(ctx context.Context, id int) error {
+	defer pst.cache.invalidate(ctx, userCacheKey(id))
+	return pst.IUserRepository.SoftDelete(ctx, id)
+}
+
+func userCacheKey(id int) string {
+	return "user:" + strconv.Itoa(id)
+}
+
+// NewCachedUserRepository returns repository unchanged when cache is nil.
+func NewCachedUserRepository(repository interfaces.IUserRepository, cache interfaces.ICache) interfaces.IUserRepository {
+	if cache == nil {
+		return repository
+	}
+
+	return cachedUserRepository{
+		repository,
+		versionedCache{cache},
+	}
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/infra/cache"
+	"webapi/pkg/infra/logger"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CachedUserRepository_Should_Query_Database_Once(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnRows(sut.userRows())
+
+	first, err := sut.repo.FindById(context.Background(), 1)
+	assert.NoError(t, err)
+	second, err := sut.repo.FindById(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_CachedUserRepository_Should_Not_Cache_Missing_Users(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnRows(sut.sqlMock.NewRows([]string{"id"}))
+	sut.sqlMock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(sut.userRows())
+
+	missing, _ := sut.repo.FindById(context.Background(), 1)
+	user, err := sut.repo.FindById(context.Background(), 1)
+
+	assert.Nil(t, missing)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, user.Id)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_CachedUserRepository_Should_Not_Cache_Errors(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnError(errors.New("some error"))
+
+	user, err := sut.repo.FindById(context.Background(), 1)
+
+	assert.Nil(t, user)
+	assert.Error(t, err)
+}
+
+func Test_CachedUserRepository_Should_Invalidate_User_On_Email_Verification(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnRows(sut.userRows())
+	sut.sqlMock.ExpectPrepare("UPDATE users").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(sut.userRows())
+
+	sut.repo.FindById(context.Background(), 1)
+	err := sut.repo.MarkEmailAsVerified(context.Background(), 1)
+	sut.repo.FindById(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_CachedUserRepository_Should_Invalidate_User_On_Password_Update(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnRows(sut.userRows())
+	sut.sqlMock.ExpectPrepare("UPDATE users").ExpectExec().WithArgs(1, "new hash").WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(sut.userRows())
+
+	sut.repo.FindById(context.Background(), 1)
+	err := sut.repo.UpdatePassword(context.Background(), 1, "new hash")
+	sut.repo.FindById(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_NewCachedUserRepository_Should_Skip_Cache_When_Disabled(t *testing.T) {
+	repository := newUserRepositoryToTest().repo
+
+	sut := NewCachedUserRepository(repository, nil)
+
+	assert.Equal(t, repository, sut)
+}
+
+func Test_CachedUserRepository_Should_Invalidate_User_On_Soft_Delete(t *testing.T) {
+	sut := newCachedUserRepositoryToTest()
+
+	sut.sqlMock.ExpectPrepare("SELECT").ExpectQuery().WithArgs(1).WillReturnRows(sut.userRows())
+	sut.sqlMock.ExpectPrepare("UPDATE users").ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(sut.sqlMock.NewRows([]string{"id"}))
+
+	sut.repo.FindById(context.Background(), 1)
+	err := sut.repo.SoftDelete(context.Background(), 1)
+	user, _ := sut.repo.FindById(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_CachedUserRepository_Should_Not_Keep_A_User_Read_While_It_Is_Deleted(t *testing.T) {
+	reads := 0
+	var sut interfaces.IUserRepository
+	inner := userRepositorySpy{reads: &reads, during: func() { sut.SoftDelete(context.Background(), 1) }}
+	sut = NewCachedUserRepository(inner, cache.NewMemoryCache("users_test_race", 10, time.Minute))
+
+	sut.FindById(context.Background(), 1)
+	sut.FindById(context.Background(), 1)
+
+	assert.Equal(t, 2, reads)
+}
+
+func Test_CachedUserRepository_Should_Invalidate_User_On_Every_Instance(t *testing.T) {
+	client := newRedisClientToTest(t)
+	firstCache, _ := cache.NewUserCache(logger.NewLoggerSpy(), client)
+	secondCache, _ := cache.NewUserCache(logger.NewLoggerSpy(), client)
+	reads := 0
+	first := NewCachedUserRepository(userRepositorySpy{reads: &reads}, firstCache)
+	second := NewCachedUserRepository(userRepositorySpy{reads: &reads}, secondCache)
+	second.FindById(context.Background(), 1)
+
+	first.SoftDelete(context.Background(), 1)
+
+	assert.Eventually(t, func() bool {
+		second.FindById(context.Background(), 1)
+		return reads > 1
+	}, time.Second, time.Millisecond*10)
+}
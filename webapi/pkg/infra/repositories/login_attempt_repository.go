@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type loginAttemptRepository struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst loginAttemptRepository) Find(ctx context.Context, key string) (*entities.LoginAttempt, error) {
+	sql := `SELECT key, failures, last_failure_at, locked_until
+					FROM login_attempts
+					WHERE key = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_LOGIN_ATTEMPT, sql)
+	defer span.Finish()
+
+	entity := entities.LoginAttempt{}
+
+	if err := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, key).Scan(
+		&entity.Key,
+		&entity.Failures,
+		&entity.LastFailureAt,
+		&entity.LockedUntil,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+// RegisterFailure counts a failed attempt in a single upsert, so concurrent
+// requests hitting different instances can never lose an increment. The count
+// restarts once the key has been quiet for a whole window, measured from the
+// end of its last lockout.
+func (pst loginAttemptRepository) RegisterFailure(ctx context.Context, key string, window time.Duration) (entities.LoginAttempt, error) {
+	sql := `INSERT INTO login_attempts AS attempts
+								(key, failures, last_failure_at)
+					VALUES
+								($1, 1, CURRENT_TIMESTAMP)
+					ON CONFLICT (key) DO UPDATE SET
+								failures = CASE
+									WHEN attempts.last_failure_at < $2 AND COALESCE(attempts.locked_until, attempts.last_failure_at) < $2
+									THEN 1
+									ELSE attempts.failures + 1
+								END,
+								last_failure_at = CURRENT_TIMESTAMP
+					RETURNING key, failures, last_failure_at, locked_until`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_LOGIN_ATTEMPT, sql)
+	defer span.Finish()
+
+	entity := entities.LoginAttempt{}
+
+	if err := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, key, windowStart(window)).Scan(
+		&entity.Key,
+		&entity.Failures,
+		&entity.LastFailureAt,
+		&entity.LockedUntil,
+	); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return entities.LoginAttempt{}, err
+	}
+
+	return entity, pst.purgeStale(ctx, window)
+}
+
+func (pst loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	sql := `UPDATE login_attempts SET locked_until = $2 WHERE key = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_LOGIN_ATTEMPT, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, key, until.UTC()); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (pst loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	sql := `DELETE FROM login_attempts WHERE key = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_DELETE_LOGIN_ATTEMPT, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, key); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// purgeStale drops counters that would restart on their next failure anyway,
+// so sprayed keys do not pile up in the table.
+func (pst loginAttemptRepository) purgeStale(ctx context.Context, window time.Duration) error {
+	sql := `DELETE FROM login_attempts
+					WHERE last_failure_at < $1
+					AND (locked_until IS NULL OR locked_until < $1)`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_DELETE_LOGIN_ATTEMPT, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, windowStart(window)); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func windowStart(window time.Duration) time.Time {
+	return time.Now().Add(-window).UTC()
+}
+
+func NewLoginAttemptRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.ILoginAttemptStore {
+	return loginAttemptRepository{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Find_Login_Attempt(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+	lockedUntil := time.Now().Add(time.Minute)
+
+	rows := sut.sqlMock.NewRows([]string{"key", "failures", "last_failure_at", "locked_until"}).AddRow("ip:127.0.0.1", 3, time.Now(), lockedUntil)
+	sut.sqlMock.ExpectQuery("SELECT key, failures, last_failure_at, locked_until").WithArgs("ip:127.0.0.1").WillReturnRows(rows)
+
+	result, err := sut.repo.Find(context.Background(), "ip:127.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Failures)
+	assert.True(t, result.IsLocked(time.Now()))
+}
+
+func Test_Should_Return_Nil_When_There_Is_No_Login_Attempt(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT key, failures, last_failure_at, locked_until").WithArgs("ip:127.0.0.1").WillReturnError(sql.ErrNoRows)
+
+	result, err := sut.repo.Find(context.Background(), "ip:127.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_Should_Register_Failure_And_Purge_Stale_Attempts(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+
+	rows := sut.sqlMock.NewRows([]string{"key", "failures", "last_failure_at", "locked_until"}).AddRow("account:some@email.com", 2, time.Now(), nil)
+	sut.sqlMock.ExpectQuery("INSERT INTO login_attempts").WithArgs("account:some@email.com", sqlmock.AnyArg()).WillReturnRows(rows)
+	sut.sqlMock.ExpectExec("DELETE FROM login_attempts").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	result, err := sut.repo.RegisterFailure(context.Background(), "account:some@email.com", time.Minute*15)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Failures)
+	assert.Nil(t, result.LockedUntil)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Returns_An_Error_When_Failure_Could_Not_Be_Registered(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("INSERT INTO login_attempts").WillReturnError(errors.New("some error"))
+
+	_, err := sut.repo.RegisterFailure(context.Background(), "account:some@email.com", time.Minute)
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Lock_Login_Attempt_Key(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+	until := time.Now().Add(time.Minute)
+
+	sut.sqlMock.ExpectExec("UPDATE login_attempts SET locked_until").WithArgs("ip:127.0.0.1", until.UTC()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := sut.repo.Lock(context.Background(), "ip:127.0.0.1", until)
+
+	assert.NoError(t, err)
+}
+
+func Test_Should_Reset_Login_Attempt_Key(t *testing.T) {
+	sut := newLoginAttemptRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("DELETE FROM login_attempts WHERE key").WithArgs("account:some@email.com").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := sut.repo.Reset(context.Background(), "account:some@email.com")
+
+	assert.NoError(t, err)
+}
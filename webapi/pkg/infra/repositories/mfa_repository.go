@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type mfaRepository struct {
+	logger             interfaces.ILogger
+	dbConnection       *sql.DB
+	telemetry          telemetry.ITelemetry
+	transactionManager interfaces.ITransactionManager
+}
+
+func (pst mfaRepository) FindByUserId(ctx context.Context, userId int) (*entities.UserMfa, error) {
+	sql := `SELECT user_id, secret, enabled_at, last_used_step, created_at
+					FROM user_mfa
+					WHERE user_id = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_MFA, sql)
+	defer span.Finish()
+
+	entity := entities.UserMfa{}
+
+	if err := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, userId).Scan(
+		&entity.UserId,
+		&entity.Secret,
+		&entity.EnabledAt,
+		&entity.LastUsedStep,
+		&entity.CreatedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+// SavePending stores a new secret awaiting confirmation. An enabled enrollment
+// is never overwritten, so a stolen access token cannot silently replace it.
+func (pst mfaRepository) SavePending(ctx context.Context, userId int, secret string) error {
+	sql := `INSERT INTO user_mfa
+								(user_id, secret)
+					VALUES
+								($1, $2)
+					ON CONFLICT (user_id) DO UPDATE SET
+								secret = EXCLUDED.secret,
+								last_used_step = NULL,
+								created_at = CURRENT_TIMESTAMP
+					WHERE user_mfa.enabled_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_MFA, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId, secret); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Enable confirms the enrollment and replaces the recovery codes in one
+// transaction, so the account never ends up enabled without codes.
+func (pst mfaRepository) Enable(ctx context.Context, userId int, recoveryCodeHashes []string) error {
+	return pst.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		enabled, err := pst.exec(ctx, telemetry.TAG_SQL_UPDATE_MFA, `UPDATE user_mfa SET enabled_at = CURRENT_TIMESTAMP WHERE user_id = $1`, userId)
+		if err != nil || enabled == 0 {
+			return err
+		}
+
+		if _, err := pst.exec(ctx, telemetry.TAG_SQL_DELETE_MFA, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userId); err != nil {
+			return err
+		}
+
+		if len(recoveryCodeHashes) == 0 {
+			return nil
+		}
+
+		values := make([]string, 0, len(recoveryCodeHashes))
+		args := []interface{}{userId}
+		for i, codeHash := range recoveryCodeHashes {
+			values = append(values, fmt.Sprintf("($1, $%d)", i+2))
+			args = append(args, codeHash)
+		}
+
+		sql := `INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ` + strings.Join(values, ", ")
+		_, err = pst.exec(ctx, telemetry.TAG_SQL_INSERT_MFA, sql, args...)
+		return err
+	})
+}
+
+func (pst mfaRepository) exec(ctx context.Context, tag, sql string, args ...interface{}) (int64, error) {
+	span := pst.telemetry.InstrumentQuery(ctx, tag, sql)
+	defer span.Finish()
+
+	result, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, args...)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// MarkStepUsed records the TOTP time step of an accepted code. It returns false
+// when that step, or a later one, was already used, which makes every code
+// single use even across instances.
+func (pst mfaRepository) MarkStepUsed(ctx context.Context, userId int, step int64) (bool, error) {
+	sql := `UPDATE user_mfa
+					SET last_used_step = $2
+					WHERE user_id = $1
+					AND (last_used_step IS NULL OR last_used_step < $2)`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_MFA, sql)
+	defer span.Finish()
+
+	result, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId, step)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (pst mfaRepository) ConsumeRecoveryCode(ctx context.Context, userId int, codeHash string) (bool, error) {
+	sql := `UPDATE mfa_recovery_codes
+					SET used_at = CURRENT_TIMESTAMP
+					WHERE user_id = $1
+					AND code_hash = $2
+					AND used_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_MFA, sql)
+	defer span.Finish()
+
+	result, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId, codeHash)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (pst mfaRepository) Delete(ctx context.Context, userId int) error {
+	sql := `DELETE FROM user_mfa WHERE user_id = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_DELETE_MFA, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewMfaRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.IMfaRepository {
+	return mfaRepository{
+		logger,
+		dbConnection,
+		telemetry,
+		database.NewTransactionManager(logger, dbConnection, telemetry),
+	}
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Find_Mfa_By_User_Id(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	rows := sut.sqlMock.NewRows([]string{"user_id", "secret", "enabled_at", "last_used_step", "created_at"}).AddRow(1, "SECRET", time.Now(), int64(42), time.Now())
+	sut.sqlMock.ExpectQuery("SELECT user_id, secret, enabled_at, last_used_step, created_at").WithArgs(1).WillReturnRows(rows)
+
+	result, err := sut.repo.FindByUserId(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsEnabled())
+	assert.Equal(t, int64(42), *result.LastUsedStep)
+}
+
+func Test_Should_Return_Nil_When_User_Has_No_Mfa(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT user_id, secret").WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+	result, err := sut.repo.FindByUserId(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_Should_Save_Pending_Mfa(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("INSERT INTO user_mfa").WithArgs(1, "SECRET").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := sut.repo.SavePending(context.Background(), 1, "SECRET")
+
+	assert.NoError(t, err)
+}
+
+func Test_Should_Enable_Mfa_With_Recovery_Codes(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("UPDATE user_mfa SET enabled_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectExec("DELETE FROM mfa_recovery_codes").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	sut.sqlMock.ExpectExec("INSERT INTO mfa_recovery_codes").WithArgs(1, "hash1", "hash2").WillReturnResult(sqlmock.NewResult(0, 2))
+	sut.sqlMock.ExpectCommit()
+
+	err := sut.repo.Enable(context.Background(), 1, []string{"hash1", "hash2"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Returns_An_Error_When_Mfa_Could_Not_Be_Enabled(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectBegin()
+	sut.sqlMock.ExpectExec("UPDATE user_mfa SET enabled_at").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectExec("DELETE FROM mfa_recovery_codes").WillReturnError(errors.New("some error"))
+	sut.sqlMock.ExpectRollback()
+
+	err := sut.repo.Enable(context.Background(), 1, []string{"hash1"})
+
+	assert.Error(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Mark_Totp_Step_As_Used_Once(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("UPDATE user_mfa").WithArgs(1, int64(42)).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectExec("UPDATE user_mfa").WithArgs(1, int64(42)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	first, err := sut.repo.MarkStepUsed(context.Background(), 1, 42)
+	assert.NoError(t, err)
+	assert.True(t, first)
+
+	second, err := sut.repo.MarkStepUsed(context.Background(), 1, 42)
+	assert.NoError(t, err)
+	assert.False(t, second)
+}
+
+func Test_Should_Consume_Recovery_Code(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("UPDATE mfa_recovery_codes").WithArgs(1, "hash").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := sut.repo.ConsumeRecoveryCode(context.Background(), 1, "hash")
+
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func Test_Should_Delete_Mfa(t *testing.T) {
+	sut := newMfaRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("DELETE FROM user_mfa").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := sut.repo.Delete(context.Background(), 1)
+
+	assert.NoError(t, err)
+}
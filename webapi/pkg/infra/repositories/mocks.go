@@ -3,16 +3,25 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"sync"
+	"testing"
 	"time"
 	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/cache"
+	"webapi/pkg/infra/database"
 	"webapi/pkg/infra/logger"
 	"webapi/pkg/infra/telemetry"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
 	"github.com/opentracing/opentracing-go"
 )
 
@@ -33,7 +42,7 @@ func newUserRepositoryToTest() userRepositoryToTest {
 
 	loggerSpy := logger.NewLoggerSpy()
 	telemetry := newTelemetrySpy()
-	repository := NewUserRepository(loggerSpy, db, telemetry)
+	repository := NewUserRepository(loggerSpy, database.NewStatementCache(db), telemetry)
 
 	return userRepositoryToTest{
 		repo:         repository,
@@ -53,6 +62,308 @@ func newUserRepositoryToTest() userRepositoryToTest {
 	}
 }
 
+// newUserRepositoryToBenchmark connects to the Postgres in BENCH_DATABASE_URL
+// and seeds one user, skipping the benchmark when the variable is not set.
+func newUserRepositoryToBenchmark(b *testing.B, statements func(db *sql.DB) database.IStatementCache) (interfaces.IUserRepository, entities.User) {
+	url := os.Getenv("BENCH_DATABASE_URL")
+	if url == "" {
+		b.Skip("BENCH_DATABASE_URL is not set")
+	}
+
+	db, err := database.GetConnection("nrpostgres", url)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cache := statements(db)
+	b.Cleanup(func() {
+		cache.Close()
+		db.Close()
+	})
+
+	repository := NewUserRepository(logger.NewLoggerSpy(), cache, newTelemetrySpy())
+	user, err := repository.Create(context.Background(), dtos.CreateUserDto{
+		Name:     "Benchmark",
+		Email:    fmt.Sprintf("benchmark+%d@email.com", time.Now().UnixNano()),
+		Password: "password",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		db.Exec(`DELETE FROM users WHERE id = $1`, user.Id)
+	})
+
+	return repository, *user
+}
+
+// preparePerCallStatements reproduces the old behaviour of preparing a new
+// statement on every query, for comparison in the benchmarks.
+type preparePerCallStatements struct {
+	dbConnection *sql.DB
+	mu           sync.Mutex
+	statements   []*sql.Stmt
+}
+
+func (pst *preparePerCallStatements) Prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	stmt, err := database.Executor(ctx, pst.dbConnection).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pst.mu.Lock()
+	pst.statements = append(pst.statements, stmt)
+	pst.mu.Unlock()
+	return stmt, nil
+}
+func (pst *preparePerCallStatements) Close() error {
+	for _, stmt := range pst.statements {
+		stmt.Close()
+	}
+	return nil
+}
+
+type sqliteUserRepositoryToTest struct {
+	repo        interfaces.IUserRepository
+	transaction interfaces.ITransactionManager
+}
+
+// newSqliteDatabaseToTest opens a private in-memory database with every
+// sqlite migration applied.
+func newSqliteDatabaseToTest(t *testing.T) *sql.DB {
+	db, err := database.GetConnection(database.SqliteDriver, database.SqliteConnectionString(":memory:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := database.NewMigrator(logger.NewLoggerSpy(), db, database.SqliteDriver).Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// inLocalZoneToTest runs the test with a local time zone behind UTC, as in
+// TZ=America/Sao_Paulo.
+func inLocalZoneToTest(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("-03", -3*60*60)
+	t.Cleanup(func() { time.Local = local })
+}
+
+func newSqliteUserRepositoryToTest(t *testing.T) sqliteUserRepositoryToTest {
+	db := newSqliteDatabaseToTest(t)
+
+	return sqliteUserRepositoryToTest{
+		newSqliteUserRepositoryOn(t, db),
+		database.NewTransactionManager(logger.NewLoggerSpy(), db, newTelemetrySpy()),
+	}
+}
+
+func newSqliteUserRepositoryOn(t *testing.T, db *sql.DB) interfaces.IUserRepository {
+	statements := database.NewStatementCache(db)
+	t.Cleanup(func() { statements.Close() })
+
+	return NewSqliteUserRepository(logger.NewLoggerSpy(), statements, newTelemetrySpy())
+}
+
+type cachedUserRepositoryToTest struct {
+	repo    interfaces.IUserRepository
+	sqlMock sqlmock.Sqlmock
+}
+
+func newCachedUserRepositoryToTest() cachedUserRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewCachedUserRepository(
+		NewUserRepository(logger.NewLoggerSpy(), database.NewStatementCache(db), newTelemetrySpy()),
+		cache.NewMemoryCache("users_test", 10, time.Minute),
+	)
+
+	return cachedUserRepositoryToTest{repository, mock}
+}
+
+func (pst cachedUserRepositoryToTest) userRows() *sqlmock.Rows {
+	createdAt := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	return pst.sqlMock.NewRows(
+		[]string{"id", "name", "email", "password", "created_at", "updated_at", "deleted_at", "email_verified_at"},
+	).AddRow(1, "Name", "email@email.com", "password", createdAt, createdAt, nil, nil)
+}
+
+// newRedisClientToTest connects to an in-process server speaking the Redis
+// protocol, both closed when the test ends.
+func newRedisClientToTest(t *testing.T) *redis.Client {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+// userRepositorySpy counts the reads reaching it and runs during, when set,
+// inside the first one, like a write landing while that read is in flight.
+type userRepositorySpy struct {
+	interfaces.IUserRepository
+	reads  *int
+	during func()
+}
+
+func (pst userRepositorySpy) FindById(ctx context.Context, id int) (*entities.User, error) {
+	*pst.reads++
+	if *pst.reads == 1 && pst.during != nil {
+		pst.during()
+	}
+
+	return &entities.User{Id: id}, nil
+}
+func (pst userRepositorySpy) SoftDelete(ctx context.Context, id int) error {
+	return nil
+}
+
+type tokenDenylistRepositorySpy struct {
+	lookups *int
+	revoked bool
+}
+
+func (pst tokenDenylistRepositorySpy) Add(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	return nil
+}
+func (pst tokenDenylistRepositorySpy) Contains(ctx context.Context, tokenId string, expiresAt time.Time) (bool, error) {
+	*pst.lookups++
+	return pst.revoked, nil
+}
+
+type sessionRepositoryToTest struct {
+	repo          interfaces.ISessionRepository
+	sqlMock       sqlmock.Sqlmock
+	mockedSession entities.Session
+}
+
+func newSessionRepositoryToTest() sessionRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewSessionRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return sessionRepositoryToTest{
+		repo:    repository,
+		sqlMock: mock,
+		mockedSession: entities.Session{
+			Id:              1,
+			UserId:          1,
+			FamilyId:        "family",
+			TokenHash:       "hash",
+			ExpiresAt:       time.Now().Add(time.Hour),
+			AccessTokenId:   "token id",
+			AccessExpiresAt: time.Now().Add(time.Hour),
+			CreatedAt:       time.Now(),
+		},
+	}
+}
+
+func (pst sessionRepositoryToTest) sessionRows() *sqlmock.Rows {
+	return pst.sqlMock.NewRows(
+		[]string{"id", "user_id", "family_id", "token_hash", "expires_at", "access_token_id", "access_expires_at", "rotated_at", "revoked_at", "created_at"},
+	).AddRow(
+		pst.mockedSession.Id,
+		pst.mockedSession.UserId,
+		pst.mockedSession.FamilyId,
+		pst.mockedSession.TokenHash,
+		pst.mockedSession.ExpiresAt,
+		pst.mockedSession.AccessTokenId,
+		pst.mockedSession.AccessExpiresAt,
+		pst.mockedSession.RotatedAt,
+		pst.mockedSession.RevokedAt,
+		pst.mockedSession.CreatedAt,
+	)
+}
+
+type tokenDenylistRepositoryToTest struct {
+	repo    interfaces.ITokenDenylistRepository
+	sqlMock sqlmock.Sqlmock
+}
+
+func newTokenDenylistRepositoryToTest() tokenDenylistRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewTokenDenylistRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return tokenDenylistRepositoryToTest{repository, mock}
+}
+
+type roleRepositoryToTest struct {
+	repo    interfaces.IRoleRepository
+	sqlMock sqlmock.Sqlmock
+}
+
+func newRoleRepositoryToTest() roleRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewRoleRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return roleRepositoryToTest{repository, mock}
+}
+
+type passwordResetRepositoryToTest struct {
+	repo    interfaces.IPasswordResetRepository
+	sqlMock sqlmock.Sqlmock
+}
+
+func newPasswordResetRepositoryToTest() passwordResetRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewPasswordResetRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return passwordResetRepositoryToTest{repository, mock}
+}
+
+type loginAttemptRepositoryToTest struct {
+	repo    interfaces.ILoginAttemptStore
+	sqlMock sqlmock.Sqlmock
+}
+
+func newLoginAttemptRepositoryToTest() loginAttemptRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewLoginAttemptRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return loginAttemptRepositoryToTest{repository, mock}
+}
+
+type mfaRepositoryToTest struct {
+	repo    interfaces.IMfaRepository
+	sqlMock sqlmock.Sqlmock
+}
+
+func newMfaRepositoryToTest() mfaRepositoryToTest {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repository := NewMfaRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	return mfaRepositoryToTest{repository, mock}
+}
+
 type telemetrySpy struct{}
 
 func (telemetrySpy) GinMiddle() gin.HandlerFunc {
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type passwordResetRepository struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst passwordResetRepository) Create(ctx context.Context, dto dtos.CreatePasswordResetDto) error {
+	sql := `INSERT INTO password_reset_tokens
+								(user_id, token_hash, expires_at)
+					VALUES
+								($1, $2, $3)`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_PASSWORD_RESET, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, dto.UserId, dto.TokenHash, dto.ExpiresAt.UTC()); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Consume marks a live reset token as used and returns it. The check and the
+// update happen in one statement, so a token can never be redeemed twice.
+func (pst passwordResetRepository) Consume(ctx context.Context, tokenHash string) (*entities.PasswordResetToken, error) {
+	sql := `UPDATE password_reset_tokens
+					SET used_at = CURRENT_TIMESTAMP
+					WHERE token_hash = $1
+					AND used_at IS NULL
+					AND expires_at > CURRENT_TIMESTAMP
+					RETURNING id, user_id, token_hash, expires_at, used_at, created_at`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_PASSWORD_RESET, sql)
+	defer span.Finish()
+
+	entity := entities.PasswordResetToken{}
+
+	if err := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, tokenHash).Scan(
+		&entity.Id,
+		&entity.UserId,
+		&entity.TokenHash,
+		&entity.ExpiresAt,
+		&entity.UsedAt,
+		&entity.CreatedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst passwordResetRepository) InvalidateByUserId(ctx context.Context, userId int) error {
+	sql := `UPDATE password_reset_tokens
+					SET used_at = CURRENT_TIMESTAMP
+					WHERE user_id = $1
+					AND used_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_PASSWORD_RESET, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewPasswordResetRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.IPasswordResetRepository {
+	return passwordResetRepository{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
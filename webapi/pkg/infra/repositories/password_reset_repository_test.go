@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_Password_Reset_Token(t *testing.T) {
+	sut := newPasswordResetRepositoryToTest()
+	expiresAt := time.Now().Add(time.Hour)
+
+	sut.sqlMock.ExpectExec("INSERT INTO password_reset_tokens").WithArgs(1, "hash", expiresAt.UTC()).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := sut.repo.Create(context.Background(), dtos.CreatePasswordResetDto{UserId: 1, TokenHash: "hash", ExpiresAt: expiresAt})
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Consume_A_Live_Password_Reset_Token(t *testing.T) {
+	sut := newPasswordResetRepositoryToTest()
+	now := time.Now()
+
+	rows := sut.sqlMock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at", "created_at"}).
+		AddRow(1, 2, "hash", now.Add(time.Hour), now, now)
+	sut.sqlMock.ExpectQuery("UPDATE password_reset_tokens").WithArgs("hash").WillReturnRows(rows)
+
+	result, err := sut.repo.Consume(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.UserId)
+	assert.NotNil(t, result.UsedAt)
+}
+
+func Test_Should_Return_Nil_When_Password_Reset_Token_Is_Used_Or_Expired(t *testing.T) {
+	sut := newPasswordResetRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("UPDATE password_reset_tokens").WithArgs("hash").WillReturnError(sql.ErrNoRows)
+
+	result, err := sut.repo.Consume(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_Should_Returns_An_Error_When_Consume_Fails(t *testing.T) {
+	sut := newPasswordResetRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("UPDATE password_reset_tokens").WithArgs("hash").WillReturnError(errors.New("some error"))
+
+	_, err := sut.repo.Consume(context.Background(), "hash")
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Invalidate_Password_Reset_Tokens_By_User_Id(t *testing.T) {
+	sut := newPasswordResetRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("UPDATE password_reset_tokens").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := sut.repo.InvalidateByUserId(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
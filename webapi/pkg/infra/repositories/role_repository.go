@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type roleRepository struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst roleRepository) FindByUserId(ctx context.Context, userId int) ([]entities.Role, error) {
+	sql := `SELECT roles.id, roles.name, COALESCE(string_agg(role_permissions.permission, ',' ORDER BY role_permissions.permission), '')
+					FROM user_roles
+					INNER JOIN roles ON roles.id = user_roles.role_id
+					LEFT JOIN role_permissions ON role_permissions.role_id = roles.id
+					WHERE user_roles.user_id = $1
+					GROUP BY roles.id, roles.name
+					ORDER BY roles.name`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_ROLE, sql)
+	defer span.Finish()
+
+	rows, err := database.Executor(ctx, pst.dbConnection).QueryContext(ctx, sql, userId)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []entities.Role{}
+	for rows.Next() {
+		var permissions string
+		entity := entities.Role{}
+		if err := rows.Scan(&entity.Id, &entity.Name, &permissions); err != nil {
+			span.SetTag("error", true)
+			pst.logger.Error(err.Error())
+			return nil, err
+		}
+
+		entity.Permissions = []string{}
+		if permissions != "" {
+			entity.Permissions = strings.Split(permissions, ",")
+		}
+		roles = append(roles, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (pst roleRepository) AssignToUser(ctx context.Context, userId int, roleName string) error {
+	sql := `INSERT INTO user_roles
+								(user_id, role_id)
+					SELECT $1, id FROM roles WHERE name = $2
+					ON CONFLICT (user_id, role_id) DO NOTHING`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_USER_ROLE, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, userId, roleName); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewRoleRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.IRoleRepository {
+	return roleRepository{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
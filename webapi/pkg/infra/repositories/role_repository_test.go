@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"webapi/pkg/domain/entities"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Find_Roles_With_Permissions_By_User_Id(t *testing.T) {
+	sut := newRoleRepositoryToTest()
+
+	rows := sut.sqlMock.NewRows([]string{"id", "name", "permissions"}).
+		AddRow(1, "admin", "inventory:write,sessions:revoke").
+		AddRow(2, "customer", "")
+	sut.sqlMock.ExpectQuery("SELECT roles.id, roles.name").WithArgs(1).WillReturnRows(rows)
+
+	result, err := sut.repo.FindByUserId(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.Role{
+		{Id: 1, Name: "admin", Permissions: []string{"inventory:write", "sessions:revoke"}},
+		{Id: 2, Name: "customer", Permissions: []string{}},
+	}, result)
+}
+
+func Test_Should_Returns_An_Error_When_Find_Roles_Fails(t *testing.T) {
+	sut := newRoleRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT roles.id, roles.name").WithArgs(1).WillReturnError(errors.New("some error"))
+
+	_, err := sut.repo.FindByUserId(context.Background(), 1)
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Assign_Role_To_User(t *testing.T) {
+	sut := newRoleRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("INSERT INTO user_roles").WithArgs(1, "customer").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := sut.repo.AssignToUser(context.Background(), 1, "customer")
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Returns_An_Error_When_Assign_Role_Fails(t *testing.T) {
+	sut := newRoleRepositoryToTest()
+
+	sut.sqlMock.ExpectExec("INSERT INTO user_roles").WillReturnError(errors.New("some error"))
+
+	err := sut.repo.AssignToUser(context.Background(), 1, "customer")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type sessionRepository struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst sessionRepository) Create(ctx context.Context, dto dtos.CreateSessionDto) (*entities.Session, error) {
+	sql := `INSERT INTO sessions
+								(user_id, family_id, token_hash, expires_at, access_token_id, access_expires_at)
+					VALUES
+								($1, $2, $3, $4, $5, $6)
+					RETURNING id, user_id, family_id, token_hash, expires_at, access_token_id, access_expires_at, rotated_at, revoked_at, created_at`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_SESSION, sql)
+	defer span.Finish()
+
+	entity := entities.Session{}
+
+	row := database.Executor(ctx, pst.dbConnection).QueryRowContext(
+		ctx,
+		sql,
+		dto.UserId,
+		dto.FamilyId,
+		dto.TokenHash,
+		dto.ExpiresAt.UTC(),
+		dto.AccessTokenId,
+		dto.AccessExpiresAt.UTC(),
+	)
+	if err := scanSession(row, &entity); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst sessionRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Session, error) {
+	sql := `SELECT id, user_id, family_id, token_hash, expires_at, access_token_id, access_expires_at, rotated_at, revoked_at, created_at
+					FROM sessions
+					WHERE token_hash = $1`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_SESSION, sql)
+	defer span.Finish()
+
+	entity := entities.Session{}
+
+	row := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, tokenHash)
+	if err := scanSession(row, &entity); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst sessionRepository) FindLiveByUserId(ctx context.Context, userId int) ([]entities.Session, error) {
+	sql := `SELECT id, user_id, family_id, token_hash, expires_at, access_token_id, access_expires_at, rotated_at, revoked_at, created_at
+					FROM sessions
+					WHERE user_id = $1
+					AND access_expires_at > CURRENT_TIMESTAMP`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_SESSION, sql)
+	defer span.Finish()
+
+	rows, err := database.Executor(ctx, pst.dbConnection).QueryContext(ctx, sql, userId)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []entities.Session{}
+	for rows.Next() {
+		entity := entities.Session{}
+		if err := scanSession(rows, &entity); err != nil {
+			span.SetTag("error", true)
+			pst.logger.Error(err.Error())
+			return nil, err
+		}
+		sessions = append(sessions, entity)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (pst sessionRepository) MarkAsRotated(ctx context.Context, id int) (bool, error) {
+	sql := `UPDATE sessions
+					SET rotated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+					AND rotated_at IS NULL
+					AND revoked_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_SESSION, sql)
+	defer span.Finish()
+
+	result, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, id)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (pst sessionRepository) RevokeFamily(ctx context.Context, familyId string) error {
+	sql := `UPDATE sessions
+					SET revoked_at = CURRENT_TIMESTAMP
+					WHERE family_id = $1
+					AND revoked_at IS NULL`
+
+	return pst.exec(ctx, sql, familyId)
+}
+
+func (pst sessionRepository) RevokeByAccessTokenId(ctx context.Context, accessTokenId string) error {
+	sql := `UPDATE sessions
+					SET revoked_at = CURRENT_TIMESTAMP
+					WHERE family_id IN (SELECT family_id FROM sessions WHERE access_token_id = $1)
+					AND revoked_at IS NULL`
+
+	return pst.exec(ctx, sql, accessTokenId)
+}
+
+func (pst sessionRepository) RevokeAllByUserId(ctx context.Context, userId int) error {
+	sql := `UPDATE sessions
+					SET revoked_at = CURRENT_TIMESTAMP
+					WHERE user_id = $1
+					AND revoked_at IS NULL`
+
+	return pst.exec(ctx, sql, userId)
+}
+
+func (pst sessionRepository) exec(ctx context.Context, sql string, args ...interface{}) error {
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_SESSION, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, args...); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+type sessionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row sessionScanner, entity *entities.Session) error {
+	return row.Scan(
+		&entity.Id,
+		&entity.UserId,
+		&entity.FamilyId,
+		&entity.TokenHash,
+		&entity.ExpiresAt,
+		&entity.AccessTokenId,
+		&entity.AccessExpiresAt,
+		&entity.RotatedAt,
+		&entity.RevokedAt,
+		&entity.CreatedAt,
+	)
+}
+
+func NewSessionRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.ISessionRepository {
+	return sessionRepository{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
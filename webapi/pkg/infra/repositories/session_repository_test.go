@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Create_Session_And_Returns_When_Execute_Correctly(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	query := "INSERT INTO sessions \\(user_id, family_id, token_hash, expires_at, access_token_id, access_expires_at\\)"
+	sut.sqlMock.ExpectQuery(query).WithArgs(
+		sut.mockedSession.UserId,
+		sut.mockedSession.FamilyId,
+		sut.mockedSession.TokenHash,
+		sut.mockedSession.ExpiresAt.UTC(),
+		sut.mockedSession.AccessTokenId,
+		sut.mockedSession.AccessExpiresAt.UTC(),
+	).WillReturnRows(sut.sessionRows())
+
+	result, err := sut.repo.Create(context.Background(), dtos.CreateSessionDto{
+		UserId:          sut.mockedSession.UserId,
+		FamilyId:        sut.mockedSession.FamilyId,
+		TokenHash:       sut.mockedSession.TokenHash,
+		ExpiresAt:       sut.mockedSession.ExpiresAt,
+		AccessTokenId:   sut.mockedSession.AccessTokenId,
+		AccessExpiresAt: sut.mockedSession.AccessExpiresAt,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, sut.mockedSession.FamilyId, result.FamilyId)
+}
+
+func Test_Should_Return_Nil_When_Session_Token_Hash_Not_Found(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT (.+) FROM sessions WHERE token_hash = \\$1").WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+	result, err := sut.repo.FindByTokenHash(context.Background(), "unknown")
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_Should_Mark_Session_As_Rotated_Only_Once(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	query := "UPDATE sessions SET rotated_at = CURRENT_TIMESTAMP WHERE id = \\$1"
+	sut.sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	first, err := sut.repo.MarkAsRotated(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.True(t, first)
+
+	second, err := sut.repo.MarkAsRotated(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.False(t, second)
+}
+
+func Test_Should_Revoke_Session_Family(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	query := "UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = \\$1"
+	sut.sqlMock.ExpectExec(query).WithArgs("family").WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err := sut.repo.RevokeFamily(context.Background(), "family")
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Find_Live_Sessions_By_User(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT (.+) FROM sessions WHERE user_id = \\$1 AND access_expires_at > CURRENT_TIMESTAMP").WithArgs(1).WillReturnRows(sut.sessionRows())
+
+	result, err := sut.repo.FindLiveByUserId(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, sut.mockedSession.AccessTokenId, result[0].AccessTokenId)
+}
+
+func Test_Should_Revoke_Session_Family_By_Access_Token_Id(t *testing.T) {
+	sut := newSessionRepositoryToTest()
+
+	query := "UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE family_id IN \\(SELECT family_id FROM sessions WHERE access_token_id = \\$1\\)"
+	sut.sqlMock.ExpectExec(query).WithArgs("token id").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := sut.repo.RevokeByAccessTokenId(context.Background(), "token id")
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SqliteTokenDenylistRepository_Should_Contain_Revoked_Token_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	sut := NewTokenDenylistRepository(logger.NewLoggerSpy(), newSqliteDatabaseToTest(t), newTelemetrySpy())
+
+	assert.NoError(t, sut.Add(context.Background(), "jti", time.Now().Add(time.Minute)))
+	revoked, err := sut.Contains(context.Background(), "jti", time.Now().Add(time.Minute))
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func Test_SqliteTokenDenylistRepository_Should_Forget_Expired_Token_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	sut := NewTokenDenylistRepository(logger.NewLoggerSpy(), newSqliteDatabaseToTest(t), newTelemetrySpy())
+
+	assert.NoError(t, sut.Add(context.Background(), "jti", time.Now().Add(-time.Minute)))
+	revoked, err := sut.Contains(context.Background(), "jti", time.Now().Add(time.Minute))
+
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func Test_SqlitePasswordResetRepository_Should_Consume_Fresh_Token_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	db := newSqliteDatabaseToTest(t)
+	user, _ := newSqliteUserRepositoryOn(t, db).Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	sut := NewPasswordResetRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	assert.NoError(t, sut.Create(context.Background(), dtos.CreatePasswordResetDto{UserId: user.Id, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Minute)}))
+	token, err := sut.Consume(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+}
+
+func Test_SqlitePasswordResetRepository_Should_Reject_Expired_Token_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	db := newSqliteDatabaseToTest(t)
+	user, _ := newSqliteUserRepositoryOn(t, db).Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	sut := NewPasswordResetRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	assert.NoError(t, sut.Create(context.Background(), dtos.CreatePasswordResetDto{UserId: user.Id, TokenHash: "hash", ExpiresAt: time.Now().Add(-time.Minute)}))
+	token, err := sut.Consume(context.Background(), "hash")
+
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+func Test_SqliteSessionRepository_Should_Find_Live_Sessions_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	db := newSqliteDatabaseToTest(t)
+	user, _ := newSqliteUserRepositoryOn(t, db).Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	sut := NewSessionRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	_, err := sut.Create(context.Background(), dtos.CreateSessionDto{
+		UserId:          user.Id,
+		FamilyId:        "live",
+		TokenHash:       "live",
+		ExpiresAt:       time.Now().Add(time.Hour),
+		AccessTokenId:   "live",
+		AccessExpiresAt: time.Now().Add(time.Minute),
+	})
+	assert.NoError(t, err)
+	_, err = sut.Create(context.Background(), dtos.CreateSessionDto{
+		UserId:          user.Id,
+		FamilyId:        "expired",
+		TokenHash:       "expired",
+		ExpiresAt:       time.Now().Add(time.Hour),
+		AccessTokenId:   "expired",
+		AccessExpiresAt: time.Now().Add(-time.Minute),
+	})
+	assert.NoError(t, err)
+
+	sessions, err := sut.FindLiveByUserId(context.Background(), user.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "live", sessions[0].AccessTokenId)
+}
+
+func Test_SqliteRoleRepository_Should_Assign_And_Find_Roles(t *testing.T) {
+	db := newSqliteDatabaseToTest(t)
+	user, _ := newSqliteUserRepositoryOn(t, db).Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	sut := NewRoleRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	assert.NoError(t, sut.AssignToUser(context.Background(), user.Id, entities.RoleAdmin))
+	assert.NoError(t, sut.AssignToUser(context.Background(), user.Id, entities.RoleAdmin))
+	roles, err := sut.FindByUserId(context.Background(), user.Id)
+
+	assert.NoError(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, entities.RoleAdmin, roles[0].Name)
+	assert.Equal(t, []string{entities.PermissionInventoryWrite, entities.PermissionSessionsRevoke}, roles[0].Permissions)
+}
+
+func Test_SqliteMfaRepository_Should_Enable_With_Single_Use_Recovery_Codes(t *testing.T) {
+	db := newSqliteDatabaseToTest(t)
+	user, _ := newSqliteUserRepositoryOn(t, db).Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	sut := NewMfaRepository(logger.NewLoggerSpy(), db, newTelemetrySpy())
+
+	assert.NoError(t, sut.SavePending(context.Background(), user.Id, "SECRET"))
+	assert.NoError(t, sut.Enable(context.Background(), user.Id, []string{"hash1", "hash2"}))
+
+	mfa, err := sut.FindByUserId(context.Background(), user.Id)
+	assert.NoError(t, err)
+	assert.True(t, mfa.IsEnabled())
+
+	first, err := sut.ConsumeRecoveryCode(context.Background(), user.Id, "hash2")
+	assert.NoError(t, err)
+	assert.True(t, first)
+
+	second, err := sut.ConsumeRecoveryCode(context.Background(), user.Id, "hash2")
+	assert.NoError(t, err)
+	assert.False(t, second)
+
+	used, err := sut.MarkStepUsed(context.Background(), user.Id, 42)
+	assert.NoError(t, err)
+	assert.True(t, used)
+
+	assert.NoError(t, sut.Delete(context.Background(), user.Id))
+	mfa, err = sut.FindByUserId(context.Background(), user.Id)
+	assert.NoError(t, err)
+	assert.Nil(t, mfa)
+}
+
+func Test_SqliteLoginAttemptRepository_Should_Count_Failures_Outside_Utc(t *testing.T) {
+	inLocalZoneToTest(t)
+	sut := NewLoginAttemptRepository(logger.NewLoggerSpy(), newSqliteDatabaseToTest(t), newTelemetrySpy())
+
+	_, err := sut.RegisterFailure(context.Background(), "ip:127.0.0.1", time.Minute)
+	assert.NoError(t, err)
+	attempt, err := sut.RegisterFailure(context.Background(), "ip:127.0.0.1", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempt.Failures)
+
+	assert.NoError(t, sut.Lock(context.Background(), "ip:127.0.0.1", time.Now().Add(time.Minute)))
+	found, err := sut.Find(context.Background(), "ip:127.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, found.IsLocked(time.Now()))
+
+	assert.NoError(t, sut.Reset(context.Background(), "ip:127.0.0.1"))
+	found, err = sut.Find(context.Background(), "ip:127.0.0.1")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func Test_SqliteLoginAttemptRepository_Should_Restart_The_Count_After_A_Quiet_Window(t *testing.T) {
+	inLocalZoneToTest(t)
+	sut := NewLoginAttemptRepository(logger.NewLoggerSpy(), newSqliteDatabaseToTest(t), newTelemetrySpy())
+
+	sut.RegisterFailure(context.Background(), "ip:127.0.0.1", time.Minute)
+	attempt, err := sut.RegisterFailure(context.Background(), "ip:127.0.0.1", -time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+}
@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+// sqliteUserRepository stores users in the embedded database selected with
+// DB_DRIVER=sqlite. It reads and writes the same columns as userRepository,
+// with the tables created by the sqlite migrations.
+type sqliteUserRepository struct {
+	logger     interfaces.ILogger
+	statements database.IStatementCache
+	telemetry  telemetry.ITelemetry
+}
+
+func (pst sqliteUserRepository) FindById(ctx context.Context, id int) (*entities.User, error) {
+	sql := `SELECT 
+								id as Id,
+								name AS Name, 
+								email AS Email, 
+								password AS Password,
+								created_at AS CreatedAt,
+								updated_at AS UpdatedAt,
+								deleted_at AS DeletedAt,
+								email_verified_at AS EmailVerifiedAt
+					FROM users
+					WHERE id = ?1
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	entity := entities.User{}
+
+	if err := prepare.QueryRowContext(ctx, id).Scan(
+		&entity.Id,
+		&entity.Name,
+		&entity.Email,
+		&entity.Password,
+		&entity.CreatedAt,
+		&entity.UpdatedAt,
+		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (pst sqliteUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	sql := `SELECT 
+								id as Id,
+								name AS Name, 
+								email AS Email, 
+								password AS Password,
+								created_at AS CreatedAt,
+								updated_at AS UpdatedAt,
+								deleted_at AS DeletedAt,
+								email_verified_at AS EmailVerifiedAt
+					FROM users
+					WHERE lower(email) = lower(?1)
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	entity := entities.User{}
+
+	if err := prepare.QueryRowContext(ctx, email).Scan(
+		&entity.Id,
+		&entity.Name,
+		&entity.Email,
+		&entity.Password,
+		&entity.CreatedAt,
+		&entity.UpdatedAt,
+		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func (pst sqliteUserRepository) Create(ctx context.Context, dto dtos.CreateUserDto) (*entities.User, error) {
+	sql := `INSERT INTO users
+								(name, email, password) 
+					VALUES
+								(?1, ?2, ?3) 
+					RETURNING id, name, email, password, created_at, updated_at, deleted_at, email_verified_at`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	entity := entities.User{}
+
+	if err := prepare.QueryRowContext(ctx, dto.Name, dto.Email, dto.Password).Scan(
+		&entity.Id,
+		&entity.Name,
+		&entity.Email,
+		&entity.Password,
+		&entity.CreatedAt,
+		&entity.UpdatedAt,
+		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
+	); err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.NewConflictError("Email already registered")
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst sqliteUserRepository) UpdatePassword(ctx context.Context, id int, password string) error {
+	sql := `UPDATE users
+					SET password = ?2, updated_at = CURRENT_TIMESTAMP
+					WHERE id = ?1
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id, password); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (pst sqliteUserRepository) MarkEmailAsVerified(ctx context.Context, id int) error {
+	sql := `UPDATE users
+					SET email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+					WHERE id = ?1
+					AND email_verified_at IS NULL
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Update changes the profile fields of a live user. A new email address has not
+// been proven yet, so changing it clears email_verified_at.
+func (pst sqliteUserRepository) Update(ctx context.Context, id int, dto dtos.UpdateUserDto) (*entities.User, error) {
+	sql := `UPDATE users
+					SET name = ?2,
+							email = ?3,
+							email_verified_at = CASE WHEN email = ?3 THEN email_verified_at ELSE NULL END,
+							updated_at = CURRENT_TIMESTAMP
+					WHERE id = ?1
+					AND deleted_at IS NULL
+					RETURNING id, name, email, password, created_at, updated_at, deleted_at, email_verified_at`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	entity := entities.User{}
+
+	if err := prepare.QueryRowContext(ctx, id, dto.Name, dto.Email).Scan(
+		&entity.Id,
+		&entity.Name,
+		&entity.Email,
+		&entity.Password,
+		&entity.CreatedAt,
+		&entity.UpdatedAt,
+		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		if isUniqueViolation(err) {
+			return nil, errors.NewConflictError("Email already registered")
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst sqliteUserRepository) SoftDelete(ctx context.Context, id int) error {
+	sql := `UPDATE users
+					SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+					WHERE id = ?1
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewSqliteUserRepository(logger interfaces.ILogger, statements database.IStatementCache, telemetry telemetry.ITelemetry) interfaces.IUserRepository {
+	return sqliteUserRepository{
+		logger,
+		statements,
+		telemetry,
+	}
+}
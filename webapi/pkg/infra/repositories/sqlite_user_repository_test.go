@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	internalErrors "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SqliteUserRepository_Should_Create_And_Find_User(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	created, err := sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	assert.NoError(t, err)
+
+	byId, err := sut.repo.FindById(context.Background(), created.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, "email@email.com", byId.Email)
+	assert.False(t, byId.CreatedAt.IsZero())
+	assert.Nil(t, byId.EmailVerifiedAt)
+
+	byEmail, err := sut.repo.FindByEmail(context.Background(), "EMAIL@email.com")
+	assert.NoError(t, err)
+	assert.Equal(t, created.Id, byEmail.Id)
+}
+
+func Test_SqliteUserRepository_Should_Return_Nil_When_User_Does_Not_Exist(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	user, err := sut.repo.FindById(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+}
+
+func Test_SqliteUserRepository_Should_Return_Conflict_When_Email_Is_Taken(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	_, err := sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Other", Email: "email@email.com", Password: "password"})
+
+	assert.IsType(t, internalErrors.ConflictError{}, err)
+}
+
+func Test_SqliteUserRepository_Should_Clear_Verification_When_Email_Changes(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	created, _ := sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	assert.NoError(t, sut.repo.MarkEmailAsVerified(context.Background(), created.Id))
+
+	sameEmail, err := sut.repo.Update(context.Background(), created.Id, dtos.UpdateUserDto{Name: "New Name", Email: "email@email.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "New Name", sameEmail.Name)
+	assert.NotNil(t, sameEmail.EmailVerifiedAt)
+
+	newEmail, err := sut.repo.Update(context.Background(), created.Id, dtos.UpdateUserDto{Name: "New Name", Email: "new@email.com"})
+	assert.NoError(t, err)
+	assert.Nil(t, newEmail.EmailVerifiedAt)
+}
+
+func Test_SqliteUserRepository_Should_Update_Password(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	created, _ := sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	err := sut.repo.UpdatePassword(context.Background(), created.Id, "new hash")
+	user, _ := sut.repo.FindById(context.Background(), created.Id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new hash", user.Password)
+}
+
+func Test_SqliteUserRepository_Should_Release_Email_On_Soft_Delete(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	created, _ := sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	assert.NoError(t, sut.repo.SoftDelete(context.Background(), created.Id))
+
+	deleted, err := sut.repo.FindById(context.Background(), created.Id)
+	assert.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	_, err = sut.repo.Create(context.Background(), dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"})
+	assert.NoError(t, err)
+}
+
+func Test_SqliteUserRepository_Should_Discard_Writes_Of_Rolled_Back_Transaction(t *testing.T) {
+	sut := newSqliteUserRepositoryToTest(t)
+
+	err := sut.transaction.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := sut.repo.Create(ctx, dtos.CreateUserDto{Name: "Name", Email: "email@email.com", Password: "password"}); err != nil {
+			return err
+		}
+
+		return errors.New("some error")
+	})
+	user, _ := sut.repo.FindByEmail(context.Background(), "email@email.com")
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+}
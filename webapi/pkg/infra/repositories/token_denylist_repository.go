@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/infra/database"
+	"webapi/pkg/infra/telemetry"
+)
+
+type tokenDenylistRepository struct {
+	logger       interfaces.ILogger
+	dbConnection *sql.DB
+	telemetry    telemetry.ITelemetry
+}
+
+func (pst tokenDenylistRepository) Add(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	sql := `INSERT INTO revoked_tokens
+								(token_id, expires_at)
+					VALUES
+								($1, $2)
+					ON CONFLICT (token_id) DO NOTHING`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_REVOKED_TOKEN, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql, tokenId, expiresAt.UTC()); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return pst.purgeExpired(ctx)
+}
+
+func (pst tokenDenylistRepository) Contains(ctx context.Context, tokenId string, expiresAt time.Time) (bool, error) {
+	sql := `SELECT EXISTS (
+						SELECT 1
+						FROM revoked_tokens
+						WHERE token_id = $1
+						AND expires_at > CURRENT_TIMESTAMP
+					)`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_REVOKED_TOKEN, sql)
+	defer span.Finish()
+
+	var exists bool
+	if err := database.Executor(ctx, pst.dbConnection).QueryRowContext(ctx, sql, tokenId).Scan(&exists); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// purgeExpired drops entries whose token would be rejected by its own expiry
+// anyway, so the denylist only ever holds tokens that are still alive.
+func (pst tokenDenylistRepository) purgeExpired(ctx context.Context) error {
+	sql := `DELETE FROM revoked_tokens WHERE expires_at <= CURRENT_TIMESTAMP`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_DELETE_REVOKED_TOKEN, sql)
+	defer span.Finish()
+
+	if _, err := database.Executor(ctx, pst.dbConnection).ExecContext(ctx, sql); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewTokenDenylistRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.ITokenDenylistRepository {
+	return tokenDenylistRepository{
+		logger,
+		dbConnection,
+		telemetry,
+	}
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Add_Token_To_Denylist_And_Purge_Expired_Entries(t *testing.T) {
+	sut := newTokenDenylistRepositoryToTest()
+	expiresAt := time.Now().Add(time.Hour)
+
+	sut.sqlMock.ExpectExec("INSERT INTO revoked_tokens").WithArgs("token id", expiresAt.UTC()).WillReturnResult(sqlmock.NewResult(0, 1))
+	sut.sqlMock.ExpectExec("DELETE FROM revoked_tokens WHERE expires_at <= CURRENT_TIMESTAMP").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := sut.repo.Add(context.Background(), "token id", expiresAt)
+
+	assert.NoError(t, err)
+	assert.NoError(t, sut.sqlMock.ExpectationsWereMet())
+}
+
+func Test_Should_Return_True_When_Token_Is_Denylisted(t *testing.T) {
+	sut := newTokenDenylistRepositoryToTest()
+
+	rows := sut.sqlMock.NewRows([]string{"exists"}).AddRow(true)
+	sut.sqlMock.ExpectQuery("SELECT EXISTS").WithArgs("token id").WillReturnRows(rows)
+
+	result, err := sut.repo.Contains(context.Background(), "token id", time.Now().Add(time.Hour))
+
+	assert.NoError(t, err)
+	assert.True(t, result)
+}
+
+func Test_Should_Returns_An_Error_When_Denylist_Query_Fails(t *testing.T) {
+	sut := newTokenDenylistRepositoryToTest()
+
+	sut.sqlMock.ExpectQuery("SELECT EXISTS").WithArgs("token id").WillReturnError(errors.New("some error"))
+
+	_, err := sut.repo.Contains(context.Background(), "token id", time.Now().Add(time.Hour))
+
+	assert.Error(t, err)
+}
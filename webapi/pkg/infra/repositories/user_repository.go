@@ -2,17 +2,20 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
+	"webapi/pkg/app/errors"
 	"webapi/pkg/app/interfaces"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/entities"
+	"webapi/pkg/infra/database"
 	"webapi/pkg/infra/telemetry"
 )
 
+// userRepository runs on statements prepared once per pool, since these
+// queries back every sign in and token validation.
 type userRepository struct {
-	logger       interfaces.ILogger
-	dbConnection *sql.DB
-	telemetry    telemetry.ITelemetry
+	logger     interfaces.ILogger
+	statements database.IStatementCache
+	telemetry  telemetry.ITelemetry
 }
 
 func (pst userRepository) FindById(ctx context.Context, id int) (*entities.User, error) {
@@ -23,7 +26,8 @@ func (pst userRepository) FindById(ctx context.Context, id int) (*entities.User,
 								password AS Password,
 								created_at AS CreatedAt,
 								updated_at AS UpdatedAt,
-								deleted_at AS DeletedAt
+								deleted_at AS DeletedAt,
+								email_verified_at AS EmailVerifiedAt
 					FROM users
 					WHERE id = $1
 					AND deleted_at IS NULL`
@@ -31,7 +35,7 @@ func (pst userRepository) FindById(ctx context.Context, id int) (*entities.User,
 	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_USER, sql)
 	defer span.Finish()
 
-	prepare, err := pst.dbConnection.PrepareContext(ctx, sql)
+	prepare, err := pst.statements.Prepare(ctx, sql)
 	if err != nil {
 		span.SetTag("error", true)
 		pst.logger.Error(err.Error())
@@ -53,8 +57,9 @@ func (pst userRepository) FindById(ctx context.Context, id int) (*entities.User,
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
 		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
 	); err != nil {
-		if err.Error() == "sql: no rows in result set" {
+		if isNoRows(err) {
 			return nil, nil
 		}
 
@@ -73,15 +78,16 @@ func (pst userRepository) FindByEmail(ctx context.Context, email string) (*entit
 								password AS Password,
 								created_at AS CreatedAt,
 								updated_at AS UpdatedAt,
-								deleted_at AS DeletedAt
+								deleted_at AS DeletedAt,
+								email_verified_at AS EmailVerifiedAt
 					FROM users
-					WHERE email = $1
+					WHERE lower(email) = lower($1)
 					AND deleted_at IS NULL`
 
 	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_SELECT_USER, sql)
 	defer span.Finish()
 
-	prepare, err := pst.dbConnection.PrepareContext(ctx, sql)
+	prepare, err := pst.statements.Prepare(ctx, sql)
 	if err != nil {
 		span.SetTag("error", true)
 		pst.logger.Error(err.Error())
@@ -103,8 +109,9 @@ func (pst userRepository) FindByEmail(ctx context.Context, email string) (*entit
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
 		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
 	); err != nil {
-		if err.Error() == "sql: no rows in result set" {
+		if isNoRows(err) {
 			return nil, nil
 		}
 
@@ -120,12 +127,12 @@ func (pst userRepository) Create(ctx context.Context, dto dtos.CreateUserDto) (*
 								(name, email, password) 
 					VALUES
 								($1, $2, $3) 
-					RETURNING *`
+					RETURNING id, name, email, password, created_at, updated_at, deleted_at, email_verified_at`
 
 	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_INSERT_USER, sql)
 	defer span.Finish()
 
-	prepare, err := pst.dbConnection.PrepareContext(ctx, sql)
+	prepare, err := pst.statements.Prepare(ctx, sql)
 	if err != nil {
 		span.SetTag("error", true)
 		pst.logger.Error(err.Error())
@@ -147,7 +154,12 @@ func (pst userRepository) Create(ctx context.Context, dto dtos.CreateUserDto) (*
 		&entity.CreatedAt,
 		&entity.UpdatedAt,
 		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
 	); err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.NewConflictError("Email already registered")
+		}
+
 		span.SetTag("error", true)
 		pst.logger.Error(err.Error())
 		return nil, err
@@ -156,10 +168,136 @@ func (pst userRepository) Create(ctx context.Context, dto dtos.CreateUserDto) (*
 	return &entity, nil
 }
 
-func NewUserRepository(logger interfaces.ILogger, dbConnection *sql.DB, telemetry telemetry.ITelemetry) interfaces.IUserRepository {
+func (pst userRepository) UpdatePassword(ctx context.Context, id int, password string) error {
+	sql := `UPDATE users
+					SET password = $2, updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id, password); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (pst userRepository) MarkEmailAsVerified(ctx context.Context, id int) error {
+	sql := `UPDATE users
+					SET email_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+					AND email_verified_at IS NULL
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Update changes the profile fields of a live user. A new email address has not
+// been proven yet, so changing it clears email_verified_at.
+func (pst userRepository) Update(ctx context.Context, id int, dto dtos.UpdateUserDto) (*entities.User, error) {
+	sql := `UPDATE users
+					SET name = $2,
+							email = $3,
+							email_verified_at = CASE WHEN email = $3 THEN email_verified_at ELSE NULL END,
+							updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+					AND deleted_at IS NULL
+					RETURNING id, name, email, password, created_at, updated_at, deleted_at, email_verified_at`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	entity := entities.User{}
+
+	if err := prepare.QueryRowContext(ctx, id, dto.Name, dto.Email).Scan(
+		&entity.Id,
+		&entity.Name,
+		&entity.Email,
+		&entity.Password,
+		&entity.CreatedAt,
+		&entity.UpdatedAt,
+		&entity.DeletedAt,
+		&entity.EmailVerifiedAt,
+	); err != nil {
+		if isNoRows(err) {
+			return nil, nil
+		}
+
+		if isUniqueViolation(err) {
+			return nil, errors.NewConflictError("Email already registered")
+		}
+
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+func (pst userRepository) SoftDelete(ctx context.Context, id int) error {
+	sql := `UPDATE users
+					SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+					AND deleted_at IS NULL`
+
+	span := pst.telemetry.InstrumentQuery(ctx, telemetry.TAG_SQL_UPDATE_USER, sql)
+	defer span.Finish()
+
+	prepare, err := pst.statements.Prepare(ctx, sql)
+	if err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	if _, err := prepare.ExecContext(ctx, id); err != nil {
+		span.SetTag("error", true)
+		pst.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func NewUserRepository(logger interfaces.ILogger, statements database.IStatementCache, telemetry telemetry.ITelemetry) interfaces.IUserRepository {
 	return userRepository{
 		logger,
-		dbConnection,
+		statements,
 		telemetry,
 	}
 }
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"webapi/pkg/app/interfaces"
+)
+
+// versionedCache files each entry under the current generation of its name.
+// invalidate starts a new one, so a read racing a write caches what it loaded
+// under a key no one reads anymore.
+type versionedCache struct {
+	interfaces.ICache
+}
+
+func (pst versionedCache) key(ctx context.Context, name string) string {
+	version, ok := pst.Get(ctx, pst.versionKey(name))
+	if !ok {
+		version = []byte(strconv.FormatInt(time.Now().UnixNano(), 36))
+		pst.Set(ctx, pst.versionKey(name), version)
+	}
+
+	return name + ":" + string(version)
+}
+
+// invalidate outlives the request, other instances only hear about it once
+// it is published.
+func (pst versionedCache) invalidate(ctx context.Context, name string) {
+	pst.Delete(context.WithoutCancel(ctx), pst.versionKey(name))
+}
+
+func (pst versionedCache) versionKey(name string) string {
+	return name + ":version"
+}
@@ -72,8 +72,36 @@ func (telemetry) GinMiddle() gin.HandlerFunc {
 }
 
 const (
+	TAG_SQL_TRANSACTION = "SQL TRANSACTION"
+	TAG_SQL_SAVEPOINT   = "SQL SAVEPOINT"
+
 	TAG_SQL_SELECT_USER = "SQL SELECT USER"
 	TAG_SQL_INSERT_USER = "SQL INSERT USER"
+	TAG_SQL_UPDATE_USER = "SQL UPDATE USER"
+
+	TAG_SQL_SELECT_SESSION = "SQL SELECT SESSION"
+	TAG_SQL_INSERT_SESSION = "SQL INSERT SESSION"
+	TAG_SQL_UPDATE_SESSION = "SQL UPDATE SESSION"
+
+	TAG_SQL_SELECT_REVOKED_TOKEN = "SQL SELECT REVOKED TOKEN"
+	TAG_SQL_INSERT_REVOKED_TOKEN = "SQL INSERT REVOKED TOKEN"
+	TAG_SQL_DELETE_REVOKED_TOKEN = "SQL DELETE REVOKED TOKEN"
+
+	TAG_SQL_SELECT_ROLE      = "SQL SELECT ROLE"
+	TAG_SQL_INSERT_USER_ROLE = "SQL INSERT USER ROLE"
+
+	TAG_SQL_INSERT_PASSWORD_RESET = "SQL INSERT PASSWORD RESET"
+	TAG_SQL_UPDATE_PASSWORD_RESET = "SQL UPDATE PASSWORD RESET"
+
+	TAG_SQL_SELECT_LOGIN_ATTEMPT = "SQL SELECT LOGIN ATTEMPT"
+	TAG_SQL_INSERT_LOGIN_ATTEMPT = "SQL INSERT LOGIN ATTEMPT"
+	TAG_SQL_UPDATE_LOGIN_ATTEMPT = "SQL UPDATE LOGIN ATTEMPT"
+	TAG_SQL_DELETE_LOGIN_ATTEMPT = "SQL DELETE LOGIN ATTEMPT"
+
+	TAG_SQL_SELECT_MFA = "SQL SELECT MFA"
+	TAG_SQL_INSERT_MFA = "SQL INSERT MFA"
+	TAG_SQL_UPDATE_MFA = "SQL UPDATE MFA"
+	TAG_SQL_DELETE_MFA = "SQL DELETE MFA"
 )
 
 func (pst *telemetry) InstrumentQuery(ctx context.Context, sqlType string, sql string) opentracing.Span {
@@ -0,0 +1,345 @@
+package token_manager
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"webapi/pkg/app/interfaces"
+)
+
+const defaultKeysReloadInterval = time.Second * 30
+const minUnknownKeyReloadInterval = time.Second * 5
+
+var readDir = ioutil.ReadDir
+var statFile = os.Stat
+
+var errNoSigningKey = errors.New("no active signing key available")
+var errUnknownKeyId = errors.New("unknown signing key id")
+
+type keyRing struct {
+	logger interfaces.ILogger
+	done   chan struct{}
+	once   sync.Once
+
+	mu        sync.RWMutex
+	signerId  string
+	signer    *rsa.PrivateKey
+	verifiers map[string]*rsa.PublicKey
+	snapshot  string
+
+	lastUnknownKeyReload time.Time
+}
+
+func (pst *keyRing) signingKey() (string, *rsa.PrivateKey, error) {
+	pst.mu.RLock()
+	defer pst.mu.RUnlock()
+
+	if pst.signer == nil {
+		return "", nil, errNoSigningKey
+	}
+
+	return pst.signerId, pst.signer, nil
+}
+
+// Tokens without kid are checked against the active signer.
+func (pst *keyRing) verificationKey(kid string) (*rsa.PublicKey, error) {
+	if key, ok := pst.lookup(kid); ok {
+		return key, nil
+	}
+
+	pst.mu.Lock()
+	throttled := time.Since(pst.lastUnknownKeyReload) < minUnknownKeyReloadInterval
+	if !throttled {
+		pst.lastUnknownKeyReload = time.Now()
+	}
+	pst.mu.Unlock()
+
+	if throttled {
+		return nil, errUnknownKeyId
+	}
+
+	if err := pst.reloadIfChanged(); err != nil {
+		pst.logger.Error(err.Error())
+	}
+
+	if key, ok := pst.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, errUnknownKeyId
+}
+
+func (pst *keyRing) lookup(kid string) (*rsa.PublicKey, bool) {
+	pst.mu.RLock()
+	defer pst.mu.RUnlock()
+
+	if kid == "" {
+		kid = pst.signerId
+	}
+
+	key, ok := pst.verifiers[kid]
+	return key, ok
+}
+
+func (pst *keyRing) publicKeys() map[string]*rsa.PublicKey {
+	pst.mu.RLock()
+	defer pst.mu.RUnlock()
+
+	keys := make(map[string]*rsa.PublicKey, len(pst.verifiers))
+	for kid, key := range pst.verifiers {
+		keys[kid] = key
+	}
+
+	return keys
+}
+
+func (pst *keyRing) load() error {
+	snapshot, err := keySourceSnapshot()
+	if err != nil {
+		return err
+	}
+
+	var signerId string
+	var signer *rsa.PrivateKey
+	var verifiers map[string]*rsa.PublicKey
+	if dir := os.Getenv("RSA_KEYS_DIR"); dir != "" {
+		signerId, signer, verifiers, err = loadKeysFromDir(dir)
+	} else {
+		signerId, signer, verifiers, err = loadLegacyKeys()
+	}
+	if err != nil {
+		return err
+	}
+
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	pst.signerId = signerId
+	pst.signer = signer
+	pst.verifiers = verifiers
+	pst.snapshot = snapshot
+
+	return nil
+}
+
+func (pst *keyRing) reloadIfChanged() error {
+	snapshot, err := keySourceSnapshot()
+	if err != nil {
+		return err
+	}
+
+	pst.mu.RLock()
+	unchanged := snapshot == pst.snapshot
+	pst.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	if err := pst.load(); err != nil {
+		return err
+	}
+
+	pst.logger.Info(fmt.Sprintf("[KeyRing::Reload] signing keys reloaded, active key id %s", pst.activeKeyId()))
+	return nil
+}
+
+func (pst *keyRing) activeKeyId() string {
+	pst.mu.RLock()
+	defer pst.mu.RUnlock()
+
+	return pst.signerId
+}
+
+// A failed reload keeps the previous keys.
+func (pst *keyRing) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pst.done:
+			return
+		case <-ticker.C:
+			if err := pst.reloadIfChanged(); err != nil {
+				pst.logger.Error(err.Error())
+			}
+		}
+	}
+}
+
+func (pst *keyRing) close() {
+	pst.once.Do(func() { close(pst.done) })
+}
+
+func loadKeysFromDir(dir string) (string, *rsa.PrivateKey, map[string]*rsa.PublicKey, error) {
+	files, err := readDir(dir)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	signers := map[string]*rsa.PrivateKey{}
+	verifiers := map[string]*rsa.PublicKey{}
+	newestId := ""
+	var newestModTime time.Time
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		extension := filepath.Ext(file.Name())
+		kid := strings.TrimSuffix(file.Name(), extension)
+
+		switch extension {
+		case ".pem":
+			keyInBytes, err := fileReader(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return "", nil, nil, err
+			}
+
+			privateKey, err := parseRSAPrivateKey(keyInBytes)
+			if err != nil {
+				return "", nil, nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+
+			signers[kid] = privateKey
+			verifiers[kid] = &privateKey.PublicKey
+
+			if newestId == "" || file.ModTime().After(newestModTime) || (file.ModTime().Equal(newestModTime) && kid > newestId) {
+				newestId = kid
+				newestModTime = file.ModTime()
+			}
+
+		case ".pub":
+			if _, ok := verifiers[kid]; ok {
+				continue
+			}
+
+			keyInBytes, err := fileReader(filepath.Join(dir, file.Name()))
+			if err != nil {
+				return "", nil, nil, err
+			}
+
+			publicKey, err := parseRSAPublicKey(keyInBytes)
+			if err != nil {
+				return "", nil, nil, fmt.Errorf("key %s: %w", kid, err)
+			}
+
+			verifiers[kid] = publicKey
+		}
+	}
+
+	signerId := os.Getenv("RSA_ACTIVE_KEY_ID")
+	if signerId == "" {
+		signerId = newestId
+	}
+
+	signer, ok := signers[signerId]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("%w: %q", errNoSigningKey, signerId)
+	}
+
+	return signerId, signer, verifiers, nil
+}
+
+func loadLegacyKeys() (string, *rsa.PrivateKey, map[string]*rsa.PublicKey, error) {
+	privateKeyInBytes, err := fileReader(os.Getenv("RSA_PRIVATE_KEY_DIR"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	privateKey, err := parseRSAPrivateKey(privateKeyInBytes)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	publicKeyInBytes, err := fileReader(os.Getenv("RSA_PUBLIC_KEY_DIR"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	publicKey, err := parseRSAPublicKey(publicKeyInBytes)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	kid := keyThumbprint(publicKey)
+	return kid, privateKey, map[string]*rsa.PublicKey{kid: publicKey}, nil
+}
+
+func keySourceSnapshot() (string, error) {
+	var entries []string
+
+	if dir := os.Getenv("RSA_KEYS_DIR"); dir != "" {
+		files, err := readDir(dir)
+		if err != nil {
+			return "", err
+		}
+
+		for _, file := range files {
+			entries = append(entries, fmt.Sprintf("%s:%d:%d", file.Name(), file.Size(), file.ModTime().UnixNano()))
+		}
+	} else {
+		for _, path := range []string{os.Getenv("RSA_PRIVATE_KEY_DIR"), os.Getenv("RSA_PUBLIC_KEY_DIR")} {
+			file, err := statFile(path)
+			if err != nil {
+				return "", err
+			}
+
+			entries = append(entries, fmt.Sprintf("%s:%d:%d", path, file.Size(), file.ModTime().UnixNano()))
+		}
+	}
+
+	sort.Strings(entries)
+	return fmt.Sprintf("%s|%s", os.Getenv("RSA_ACTIVE_KEY_ID"), strings.Join(entries, ",")), nil
+}
+
+// keyThumbprint computes the RFC 7638 JWK thumbprint of an RSA public key.
+func keyThumbprint(key *rsa.PublicKey) string {
+	jwk, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   encodeBigInt(big.NewInt(int64(key.E))),
+		Kty: "RSA",
+		N:   encodeBigInt(key.N),
+	})
+
+	hashed := sha256.Sum256(jwk)
+	return base64.RawURLEncoding.EncodeToString(hashed[:])
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func keysReloadInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("RSA_KEYS_RELOAD_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return defaultKeysReloadInterval
+	}
+
+	return interval
+}
+
+func newKeyRing(logger interfaces.ILogger) *keyRing {
+	return &keyRing{
+		logger:    logger,
+		done:      make(chan struct{}),
+		verifiers: map[string]*rsa.PublicKey{},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
+	"io/ioutil"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -66,11 +67,34 @@ func (m jwtMocked) ParseClaims(failure bool, unixTime time.Time) func(tokenStrin
 		}
 
 		return &jwt.Token{
-			Claims: &jwt.RegisteredClaims{
-				ID:        "1",
-				ExpiresAt: jwt.NewNumericDate(unixTime),
+			Claims: &accessTokenClaims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "token id",
+					Subject:   "1",
+					ExpiresAt: jwt.NewNumericDate(unixTime),
+				},
 			},
 			Valid: true,
 		}, nil
 	}
 }
+
+func (m jwtMocked) RandomReader(failure bool) func(b []byte) (int, error) {
+	return func(b []byte) (int, error) {
+		if failure {
+			return 0, errors.New("random reader error")
+		}
+
+		return len(b), nil
+	}
+}
+
+// Restore puts the real jwt and io functions back after a test mocked them.
+func (m jwtMocked) Restore() {
+	fileReader = ioutil.ReadFile
+	parseRSAPrivateKey = jwt.ParseRSAPrivateKeyFromPEM
+	parseRSAPublicKey = jwt.ParseRSAPublicKeyFromPEM
+	claimsGenerator = jwt.NewWithClaims
+	parseClaims = jwt.ParseWithClaims
+	randomReader = rand.Read
+}
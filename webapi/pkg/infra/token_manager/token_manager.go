@@ -1,10 +1,16 @@
 package token_manager
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
@@ -16,6 +22,7 @@ import (
 
 type tokenManager struct {
 	logger interfaces.ILogger
+	keys   *keyRing
 }
 
 var fileReader = ioutil.ReadFile
@@ -23,32 +30,49 @@ var parseRSAPrivateKey = jwt.ParseRSAPrivateKeyFromPEM
 var parseRSAPublicKey = jwt.ParseRSAPublicKeyFromPEM
 var claimsGenerator = jwt.NewWithClaims
 var parseClaims = jwt.ParseWithClaims
+var randomReader = rand.Read
+
+const opaqueTokenSize = 32
+
+// accessTokenClaims carries the authorization granted when the token was
+// issued, so route guards don't need a database round trip per request.
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	Use         string   `json:"token_use,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
 
 func (pst tokenManager) GenerateToken(tokenData dtos.TokenDataDto) (string, error) {
-	privateKeyInBytes, err := fileReader(os.Getenv("RSA_PRIVATE_KEY_DIR"))
-	if err != nil {
-		pst.logger.Error(err.Error())
-		return "", err
-	}
-
-	privateKey, err := parseRSAPrivateKey(privateKeyInBytes)
+	kid, privateKey, err := pst.keys.signingKey()
 	if err != nil {
 		pst.logger.Error(err.Error())
 		return "", err
 	}
 
-	claims := jwt.RegisteredClaims{
-		Audience: jwt.ClaimStrings{
-			tokenData.Audience,
+	claims := accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience: jwt.ClaimStrings{
+				tokenData.Audience,
+			},
+			Issuer:    os.Getenv("APP_ISSUER"),
+			ExpiresAt: jwt.NewNumericDate(tokenData.ExpireIn),
+			ID:        tokenData.TokenId,
+			Subject:   fmt.Sprintf("%d", tokenData.Id),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
-		Issuer:    os.Getenv("APP_ISSUER"),
-		ExpiresAt: jwt.NewNumericDate(tokenData.ExpireIn),
-		ID:        fmt.Sprintf("%d", tokenData.Id),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		NotBefore: jwt.NewNumericDate(time.Now()),
+		Use:         tokenData.Use,
+		Email:       tokenData.Email,
+		Roles:       tokenData.Roles,
+		Permissions: tokenData.Permissions,
 	}
 
-	token, err := claimsGenerator(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	jwtToken := claimsGenerator(jwt.SigningMethodRS256, claims)
+	jwtToken.Header["kid"] = kid
+
+	token, err := jwtToken.SignedString(privateKey)
 	if err != nil {
 		pst.logger.Error(err.Error())
 		return "", err
@@ -58,26 +82,22 @@ func (pst tokenManager) GenerateToken(tokenData dtos.TokenDataDto) (string, erro
 }
 
 func (pst tokenManager) VerifyToken(accessToken string) (*dtos.SessionDto, error) {
-	publicKeyInBytes, err := fileReader(os.Getenv("RSA_PUBLIC_KEY_DIR"))
-	if err != nil {
-		pst.logger.Error(err.Error())
-		return nil, err
-	}
-
-	publicKey, err := parseRSAPublicKey(publicKeyInBytes)
-	if err != nil {
-		pst.logger.Error(err.Error())
-		return nil, err
-	}
-
 	token, err := parseClaims(
 		accessToken,
-		&jwt.RegisteredClaims{},
+		&accessTokenClaims{},
 		func(jwtToken *jwt.Token) (interface{}, error) {
 			if _, ok := jwtToken.Method.(*jwt.SigningMethodRSA); !ok {
-				pst.logger.Error(err.Error())
+				pst.logger.Error(fmt.Sprintf("unexpected signing method %v", jwtToken.Header["alg"]))
 				return nil, errors.New("unexpected method")
 			}
+
+			kid, _ := jwtToken.Header["kid"].(string)
+			publicKey, err := pst.keys.verificationKey(kid)
+			if err != nil {
+				pst.logger.Error(err.Error())
+				return nil, err
+			}
+
 			return publicKey, nil
 		},
 	)
@@ -85,7 +105,7 @@ func (pst tokenManager) VerifyToken(accessToken string) (*dtos.SessionDto, error
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*accessTokenClaims)
 	if !ok || !token.Valid {
 		return nil, errors.New("invalid token")
 	}
@@ -94,18 +114,76 @@ func (pst tokenManager) VerifyToken(accessToken string) (*dtos.SessionDto, error
 		return nil, errors.New("jwt is expired")
 	}
 
-	id, _ := strconv.Atoi(claims.ID)
+	id, _ := strconv.Atoi(claims.Subject)
 
 	return &dtos.SessionDto{
 		Id:          id,
+		TokenId:     claims.ID,
 		AccessToken: accessToken,
 		Kind:        os.Getenv("TOKEN_KIND"),
 		ExpireIn:    claims.ExpiresAt.Time,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		Use:         claims.Use,
+		Email:       claims.Email,
 	}, nil
 }
 
+func (pst tokenManager) JsonWebKeys() []dtos.JsonWebKeyDto {
+	keys := pst.keys.publicKeys()
+
+	kids := make([]string, 0, len(keys))
+	for kid := range keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	result := make([]dtos.JsonWebKeyDto, 0, len(kids))
+	for _, kid := range kids {
+		result = append(result, dtos.JsonWebKeyDto{
+			Kid: kid,
+			Kty: "RSA",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Use: "sig",
+			N:   encodeBigInt(keys[kid].N),
+			E:   encodeBigInt(big.NewInt(int64(keys[kid].E))),
+		})
+	}
+
+	return result
+}
+
+func (pst tokenManager) GenerateOpaqueToken() (string, error) {
+	buffer := make([]byte, opaqueTokenSize)
+	if _, err := randomReader(buffer); err != nil {
+		pst.logger.Error(err.Error())
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+func (tokenManager) HashOpaqueToken(token string) string {
+	hashed := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashed[:])
+}
+
+// Close stops reloading the signing keys.
+func (pst tokenManager) Close() error {
+	pst.keys.close()
+	return nil
+}
+
 func NewTokenManager(logger interfaces.ILogger) interfaces.ITokenManager {
+	keys := newKeyRing(logger)
+	if err := keys.load(); err != nil {
+		logger.Error(err.Error())
+	}
+
+	go keys.watch(keysReloadInterval())
+
 	return tokenManager{
 		logger,
+		keys,
 	}
 }
@@ -1,103 +1,381 @@
 package token_manager
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 	"webapi/pkg/domain/dtos"
 	"webapi/pkg/infra/logger"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 )
 
-var manager = NewTokenManager(logger.NewLoggerSpy())
 var tokenData = dtos.TokenDataDto{
 	Id:       1,
+	TokenId:  "token id",
 	ExpireIn: time.Now().Add(time.Hour),
 	Audience: "audience",
 }
 
-func Test_Should_CreateToken_Correctly(t *testing.T) {
-	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
-	parseRSAPrivateKey = jwtMock.ParseRSAPrivateKey(false)
-	claimsGenerator = jwtMock.ClaimsGenerator(false)
+func writeKey(t *testing.T, dir, kid string, modTime time.Time, retired bool) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, kid+".pem")
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}
+	if retired {
+		publicKeyInBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path = filepath.Join(dir, kid+".pub")
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyInBytes}
+	}
+
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey
+}
+
+func newTokenManagerToTest() (tokenManager, error) {
+	jwtMocked{}.Restore()
+
+	keys := newKeyRing(logger.NewLoggerSpy())
+	err := keys.load()
+
+	return tokenManager{logger.NewLoggerSpy(), keys}, err
+}
+
+func signWith(t *testing.T, privateKey *rsa.PrivateKey, kid string) string {
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		ID:        "token id",
+		Subject:   "1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	if kid != "" {
+		jwtToken.Header["kid"] = kid
+	}
+
+	token, err := jwtToken.SignedString(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func Test_Should_CreateToken_With_The_Active_Key_Id(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), false)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
 
 	token, err := manager.GenerateToken(tokenData)
+	assert.NoError(t, err)
 
-	assert.NotNil(t, token)
-	assert.Nil(t, err)
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, &jwt.RegisteredClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-11", parsed.Header["kid"])
+
+	session, err := manager.VerifyToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, session.Id)
+	assert.Equal(t, "token id", session.TokenId)
 }
 
-func Test_Should_Return_Err_If_Some_Error_Occur_In_ReadRSAPrivateKey(t *testing.T) {
-	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(true)
+func Test_Should_Carry_Roles_And_Permissions_In_The_Token(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
 
-	_, err := manager.GenerateToken(tokenData)
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
 
-	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "error when try to read rsa private key")
+	data := tokenData
+	data.Use = dtos.TokenUseAccess
+	data.Roles = []string{"admin"}
+	data.Permissions = []string{"inventory:write", "sessions:revoke"}
+
+	token, err := manager.GenerateToken(data)
+	assert.NoError(t, err)
+
+	session, err := manager.VerifyToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, dtos.TokenUseAccess, session.Use)
+	assert.Equal(t, []string{"admin"}, session.Roles)
+	assert.Equal(t, []string{"inventory:write", "sessions:revoke"}, session.Permissions)
 }
 
-func Test_Should_Return_Err_If_Some_Error_Occur_In_ParseRSAPrivateKey(t *testing.T) {
-	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
-	parseRSAPrivateKey = jwtMock.ParseRSAPrivateKey(true)
+func Test_Should_Carry_The_Email_In_The_Token(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
 
-	_, err := manager.GenerateToken(tokenData)
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
 
-	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "parse rsa private key")
+	data := tokenData
+	data.Use = dtos.TokenUseEmailVerification
+	data.Email = "email@email.com"
+
+	token, err := manager.GenerateToken(data)
+	assert.NoError(t, err)
+
+	session, err := manager.VerifyToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "email@email.com", session.Email)
 }
 
-// func TestCreateToken_ShouldReturnErrIfSomeErrorOccurInClaimGenerator(t *testing.T) {
-// 	jwtMock := mocks.NewJwtMock(false, false, true)
-// 	fileReader = jwtMock.FileReader
-// 	parseRSAPrivateKey = jwtMock.ParseRSAPrivateKey
-// 	claimsGenerator = jwtMock.ClaimsGenerator
+func Test_Should_Sign_With_The_Configured_Active_Key(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	t.Setenv("RSA_ACTIVE_KEY_ID", "2021-10")
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), false)
+	writeKey(t, dir, "2021-11", time.Now(), false)
 
-// 	_, err := manager.GenerateToken(tokenData)
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
 
-// 	assert.NotNil(t, err)
-// }
+	token, err := manager.GenerateToken(tokenData)
+	assert.NoError(t, err)
 
-func Test_Should_Verify_Token_Correctly(t *testing.T) {
-	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
-	parseRSAPublicKey = jwtMock.ParseRSAPublicKey(false)
-	parseClaims = jwtMock.ParseClaims(false, time.Now().Add(time.Hour))
+	parsed, _, _ := new(jwt.Parser).ParseUnverified(token, &jwt.RegisteredClaims{})
+	assert.Equal(t, "2021-10", parsed.Header["kid"])
+}
+
+func Test_Should_Return_Err_If_Active_Key_Id_Has_No_Private_Key(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	t.Setenv("RSA_ACTIVE_KEY_ID", "2021-10")
+	writeKey(t, dir, "2021-10", time.Now(), true)
 
-	token, err := manager.VerifyToken("token")
+	manager, err := newTokenManagerToTest()
+	assert.ErrorIs(t, err, errNoSigningKey)
 
-	assert.NotNil(t, token)
-	assert.Nil(t, err)
+	_, err = manager.GenerateToken(tokenData)
+	assert.ErrorIs(t, err, errNoSigningKey)
 }
 
-func Test_Should_Return_Err_If_Some_Error_Occur_In_ReadRSAPublicKey(t *testing.T) {
+func Test_Should_Verify_Token_Signed_By_A_Retired_Key(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	retired := writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), true)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	session, err := manager.VerifyToken(signWith(t, retired, "2021-10"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, session.Id)
+}
+
+func Test_Should_Verify_Token_Without_Kid_With_The_Active_Key(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	active := writeKey(t, dir, "2021-11", time.Now(), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	_, err = manager.VerifyToken(signWith(t, active, ""))
+
+	assert.NoError(t, err)
+}
+
+func Test_Should_Return_Err_If_Kid_Is_Unknown(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	active := writeKey(t, dir, "2021-11", time.Now(), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	_, err = manager.VerifyToken(signWith(t, active, "unknown"))
+
+	assert.EqualError(t, err, errUnknownKeyId.Error())
+}
+
+func Test_Should_Return_Err_If_Token_Was_Signed_By_Another_Key(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	_, err = manager.VerifyToken(signWith(t, other, "2021-11"))
+
+	assert.Error(t, err)
+}
+
+func Test_Should_Reload_Keys_When_A_New_Key_Is_Added(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	oldToken, _ := manager.GenerateToken(tokenData)
+
+	writeKey(t, dir, "2021-11", time.Now(), false)
+	assert.NoError(t, manager.keys.reloadIfChanged())
+
+	newToken, err := manager.GenerateToken(tokenData)
+	assert.NoError(t, err)
+
+	parsed, _, _ := new(jwt.Parser).ParseUnverified(newToken, &jwt.RegisteredClaims{})
+	assert.Equal(t, "2021-11", parsed.Header["kid"])
+
+	_, err = manager.VerifyToken(oldToken)
+	assert.NoError(t, err)
+}
+
+func Test_Should_Reload_Keys_When_Token_Has_An_Unseen_Kid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	rotated := writeKey(t, dir, "2021-11", time.Now(), false)
+
+	_, err = manager.VerifyToken(signWith(t, rotated, "2021-11"))
+
+	assert.NoError(t, err)
+}
+
+func Test_Should_Keep_Previous_Keys_If_Reload_Fails(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "2021-11.pem"), []byte("half written"), 0600))
+
+	assert.Error(t, manager.keys.reloadIfChanged())
+
+	_, err = manager.GenerateToken(tokenData)
+	assert.NoError(t, err)
+}
+
+func Test_Should_Stop_Watching_Keys_When_Closed(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now(), false)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	stopped := make(chan struct{})
+	go func() {
+		manager.keys.watch(time.Millisecond)
+		close(stopped)
+	}()
+
+	assert.NoError(t, manager.Close())
+	assert.NoError(t, manager.Close())
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("key ring still watching after Close")
+	}
+}
+
+func Test_Should_Load_Legacy_Key_Pair_With_Thumbprint_Kid(t *testing.T) {
+	dir := t.TempDir()
+	privateKey := writeKey(t, dir, "id_rsa", time.Now(), false)
+	publicKeyInBytes, _ := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	ioutil.WriteFile(filepath.Join(dir, "id_rsa.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyInBytes}), 0600)
+	t.Setenv("RSA_KEYS_DIR", "")
+	t.Setenv("RSA_PRIVATE_KEY_DIR", filepath.Join(dir, "id_rsa.pem"))
+	t.Setenv("RSA_PUBLIC_KEY_DIR", filepath.Join(dir, "id_rsa.pub"))
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	keys := manager.JsonWebKeys()
+
+	assert.Len(t, keys, 1)
+	assert.Equal(t, keyThumbprint(&privateKey.PublicKey), keys[0].Kid)
+}
+
+func Test_Should_Return_Err_If_Some_Error_Occur_In_ReadRSAPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+
 	jwtMock := jwtMocked{}
+	keys := newKeyRing(logger.NewLoggerSpy())
 	fileReader = jwtMock.FileReader(true)
+	defer jwtMock.Restore()
+
+	err := keys.load()
 
-	token, err := manager.VerifyToken("token")
+	assert.EqualError(t, err, "error when try to read rsa private key")
+}
+
+func Test_Should_Return_Err_If_Some_Error_Occur_In_ParseRSAPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+
+	jwtMock := jwtMocked{}
+	keys := newKeyRing(logger.NewLoggerSpy())
+	parseRSAPrivateKey = jwtMock.ParseRSAPrivateKey(true)
+	defer jwtMock.Restore()
 
-	assert.Nil(t, token)
-	assert.Equal(t, err.Error(), "error when try to read rsa private key")
+	err := keys.load()
+
+	assert.EqualError(t, err, "key 2021-11: parse rsa private key")
 }
 
 func Test_Should_Return_Err_If_Some_Error_Occur_In_ParseRSAPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-10", time.Now(), true)
+
 	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
+	keys := newKeyRing(logger.NewLoggerSpy())
 	parseRSAPublicKey = jwtMock.ParseRSAPublicKey(true)
+	defer jwtMock.Restore()
 
-	_, err := manager.VerifyToken("token")
+	err := keys.load()
 
-	assert.NotNil(t, err)
-	assert.Equal(t, err.Error(), "parse rsa public key")
+	assert.EqualError(t, err, "key 2021-10: parse rsa public key")
 }
 
 func Test_Should_Return_Err_If_Some_Error_Occur_When_ParseClaims(t *testing.T) {
+	manager, _ := newTokenManagerToTest()
 	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
-	parseRSAPublicKey = jwtMock.ParseRSAPublicKey(false)
 	parseClaims = jwtMock.ParseClaims(true, time.Now().Add(time.Hour))
+	defer jwtMock.Restore()
 
 	_, err := manager.VerifyToken("token")
 
@@ -106,13 +384,70 @@ func Test_Should_Return_Err_If_Some_Error_Occur_When_ParseClaims(t *testing.T) {
 }
 
 func Test_Should_Return_Err_If_Token_Expired(t *testing.T) {
+	manager, _ := newTokenManagerToTest()
 	jwtMock := jwtMocked{}
-	fileReader = jwtMock.FileReader(false)
-	parseRSAPublicKey = jwtMock.ParseRSAPublicKey(false)
 	parseClaims = jwtMock.ParseClaims(false, time.Now().Add(-time.Hour))
+	defer jwtMock.Restore()
 
 	_, err := manager.VerifyToken("token")
 
 	assert.NotNil(t, err)
 	assert.Equal(t, err.Error(), "jwt is expired")
 }
+
+func Test_Should_Return_Json_Web_Keys_Sorted_By_Kid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("RSA_KEYS_DIR", dir)
+	writeKey(t, dir, "2021-11", time.Now(), false)
+	writeKey(t, dir, "2021-10", time.Now().Add(-time.Hour), true)
+
+	manager, err := newTokenManagerToTest()
+	assert.NoError(t, err)
+
+	keys := manager.JsonWebKeys()
+
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "2021-10", keys[0].Kid)
+	assert.Equal(t, "2021-11", keys[1].Kid)
+	for _, key := range keys {
+		assert.Equal(t, "RSA", key.Kty)
+		assert.Equal(t, "RS256", key.Alg)
+		assert.Equal(t, "sig", key.Use)
+		assert.Equal(t, "AQAB", key.E)
+		assert.NotEmpty(t, key.N)
+	}
+}
+
+func Test_Should_Generate_Opaque_Token_Correctly(t *testing.T) {
+	manager, _ := newTokenManagerToTest()
+	jwtMock := jwtMocked{}
+	randomReader = jwtMock.RandomReader(false)
+	defer jwtMock.Restore()
+
+	token, err := manager.GenerateOpaqueToken()
+
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+}
+
+func Test_Should_Return_Err_If_Some_Error_Occur_When_Generate_Opaque_Token(t *testing.T) {
+	manager, _ := newTokenManagerToTest()
+	jwtMock := jwtMocked{}
+	randomReader = jwtMock.RandomReader(true)
+	defer jwtMock.Restore()
+
+	_, err := manager.GenerateOpaqueToken()
+
+	assert.EqualError(t, err, "random reader error")
+}
+
+func Test_Should_Hash_Opaque_Token_Deterministically(t *testing.T) {
+	manager, _ := newTokenManagerToTest()
+
+	first := manager.HashOpaqueToken("token")
+	second := manager.HashOpaqueToken("token")
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, manager.HashOpaqueToken("other token"))
+	assert.Len(t, first, 64)
+}
@@ -0,0 +1,116 @@
+package totp
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B secret for HMAC-SHA1.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func freezeTime(at int64) func() {
+	now = func() time.Time { return time.Unix(at, 0) }
+	return func() { now = time.Now }
+}
+
+func Test_Totp_Should_Match_RFC6238_Vectors(t *testing.T) {
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for at, code := range vectors {
+		restore := freezeTime(at)
+		step, ok := NewTotp().Verify(rfcSecret, code)
+		restore()
+
+		assert.True(t, ok, "code %s at %d", code, at)
+		assert.Equal(t, at/period, step)
+	}
+}
+
+func Test_Totp_Should_Accept_One_Step_Of_Drift(t *testing.T) {
+	defer freezeTime(59 + period)()
+
+	step, ok := NewTotp().Verify(rfcSecret, "287082")
+
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+}
+
+func Test_Totp_Should_Reject_Codes_Outside_The_Window(t *testing.T) {
+	defer freezeTime(59 + period*3)()
+
+	_, ok := NewTotp().Verify(rfcSecret, "287082")
+
+	assert.False(t, ok)
+}
+
+func Test_Totp_Should_Reject_Malformed_Input(t *testing.T) {
+	sut := NewTotp()
+
+	_, ok := sut.Verify("not base32!", "287082")
+	assert.False(t, ok)
+
+	_, ok = sut.Verify(rfcSecret, "2870")
+	assert.False(t, ok)
+}
+
+func Test_Totp_Should_Generate_A_Verifiable_Secret(t *testing.T) {
+	sut := NewTotp()
+
+	secret, err := sut.GenerateSecret()
+	assert.NoError(t, err)
+
+	key, _ := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	_, ok := sut.Verify(secret, generateCode(key, time.Now().Unix()/period))
+	assert.True(t, ok)
+}
+
+func Test_Totp_Should_Return_Error_If_Random_Fails(t *testing.T) {
+	randomReader = func(b []byte) (int, error) { return 0, errors.New("some error") }
+	defer func() { randomReader = rand.Read }()
+
+	_, err := NewTotp().GenerateSecret()
+	assert.Error(t, err)
+
+	_, err = NewTotp().GenerateRecoveryCodes(1)
+	assert.Error(t, err)
+}
+
+func Test_Totp_Should_Build_Provisioning_Uri(t *testing.T) {
+	uri := NewTotp().ProvisioningUri("SECRET", "GoWebApi", "some@email.com")
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/GoWebApi:some@email.com", parsed.Path)
+	assert.Equal(t, "SECRET", parsed.Query().Get("secret"))
+	assert.Equal(t, "GoWebApi", parsed.Query().Get("issuer"))
+}
+
+func Test_Totp_Should_Generate_Distinct_Recovery_Codes(t *testing.T) {
+	codes, err := NewTotp().GenerateRecoveryCodes(10)
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, regexp.MustCompile(`^[a-z2-7]{4}(-[a-z2-7]{4}){3}$`), code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
@@ -8,16 +8,24 @@ import (
 	"webapi/pkg/interfaces/http/models"
 )
 
+const defaultPageLimit = 20
+
 type IInventoryHandler interface {
 	CreateProduct(httpRequest http.HttpRequest) http.HttpResponse
 	GetById(httpRequest http.HttpRequest) http.HttpResponse
+	List(httpRequest http.HttpRequest) http.HttpResponse
+	GetByType(httpRequest http.HttpRequest) http.HttpResponse
+	Update(httpRequest http.HttpRequest) http.HttpResponse
 }
 
 type inventoryHandler struct {
 	logger               interfaces.ILogger
 	validator            interfaces.IValidator
 	getByIdUseCase       usecases.IGetProductByIdUseCase
+	getProductsUseCase   usecases.IGetProductsUseCase
+	getByTypeUseCase     usecases.IGetProductsByTypeUseCase
 	createProductUseCase usecases.ICreateProductUseCase
+	updateProductUseCase usecases.IUpdateProductUseCase
 }
 
 func (pst inventoryHandler) GetById(httpRequest http.HttpRequest) http.HttpResponse {
@@ -34,6 +42,45 @@ func (pst inventoryHandler) GetById(httpRequest http.HttpRequest) http.HttpRespo
 	return http.Ok(models.ToProductResponse(result), nil)
 }
 
+func (pst inventoryHandler) List(httpRequest http.HttpRequest) http.HttpResponse {
+	limit, err := httpRequest.QueryInt("limit", defaultPageLimit)
+	if err != nil {
+		return http.BadRequest(models.StringToErrorResponse("limit must be an integer"), nil)
+	}
+
+	offset, err := httpRequest.QueryInt("offset", 0)
+	if err != nil {
+		return http.BadRequest(models.StringToErrorResponse("offset must be an integer"), nil)
+	}
+
+	query := models.PageQueryModel{Limit: limit, Offset: offset}
+	if validationErrs := pst.validator.ValidateStruct(query); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	result, err := pst.getProductsUseCase.Perform(httpRequest.Ctx, limit, offset)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToProductsPageResponse(result, httpRequest.Path), nil)
+}
+
+func (pst inventoryHandler) GetByType(httpRequest http.HttpRequest) http.HttpResponse {
+	productType, ok := httpRequest.Params["type"]
+	if !ok || productType == "" {
+		return http.BadRequest(models.StringToErrorResponse("type is required"), nil)
+	}
+
+	result, err := pst.getByTypeUseCase.Perform(httpRequest.Ctx, productType)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToProductsResponse(result), nil)
+}
+
 func (pst inventoryHandler) CreateProduct(httpRequest http.HttpRequest) http.HttpResponse {
 	model := models.CreateProductModel{}
 	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
@@ -54,16 +101,51 @@ func (pst inventoryHandler) CreateProduct(httpRequest http.HttpRequest) http.Htt
 	return http.Created(models.ToProductResponse(result), nil)
 }
 
+func (pst inventoryHandler) Update(httpRequest http.HttpRequest) http.HttpResponse {
+	id, ok := httpRequest.Params["id"]
+	if !ok {
+		return http.BadRequest(models.StringToErrorResponse("id is required"), nil)
+	}
+
+	model := models.UpdateProductModel{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if model.IsEmpty() {
+		return http.BadRequest(models.StringToErrorResponse("at least one field is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	result, err := pst.updateProductUseCase.Perform(httpRequest.Ctx, model.ToUpdateProductDto(id))
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToProductResponse(result), nil)
+}
+
 func NewInventoryHandler(
 	logger interfaces.ILogger,
 	validator interfaces.IValidator,
 	getByIdUseCase usecases.IGetProductByIdUseCase,
+	getProductsUseCase usecases.IGetProductsUseCase,
+	getByTypeUseCase usecases.IGetProductsByTypeUseCase,
 	crateProductUseCase usecases.ICreateProductUseCase,
+	updateProductUseCase usecases.IUpdateProductUseCase,
 ) IInventoryHandler {
 	return inventoryHandler{
 		logger,
 		validator,
 		getByIdUseCase,
+		getProductsUseCase,
+		getByTypeUseCase,
 		crateProductUseCase,
+		updateProductUseCase,
 	}
 }
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"webapi/pkg/app/errors"
+	internalHttp "webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Inventory_Should_Execute_List_Correctly(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.List(internalHttp.HttpRequest{
+		Query: map[string]string{"limit": "20", "offset": "20"},
+		Path:  "/api/v1/inventory",
+	})
+
+	page := result.Body.(models.PageModel)
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, page.Total, 45)
+	assert.Equal(t, *page.Links.Next, "/api/v1/inventory?limit=20&offset=40")
+	assert.Equal(t, *page.Links.Prev, "/api/v1/inventory?limit=20&offset=0")
+}
+
+func Test_Inventory_Should_List_With_Default_Bounds(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.List(internalHttp.HttpRequest{Path: "/api/v1/inventory"})
+
+	page := result.Body.(models.PageModel)
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, page.Limit, 20)
+	assert.Equal(t, page.Offset, 0)
+	assert.Nil(t, page.Links.Prev)
+}
+
+func Test_Inventory_Should_Returns_BadRequest_If_List_Limit_Is_Not_A_Number(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.List(internalHttp.HttpRequest{
+		Query: map[string]string{"limit": "abc"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Inventory_Should_Returns_BadRequest_If_List_Bounds_Are_Invalid(t *testing.T) {
+	sut := newInventoryHandlerToTest(true, nil)
+
+	result := sut.handler.List(internalHttp.HttpRequest{
+		Query: map[string]string{"limit": "1000"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Inventory_Should_Returns_Error_If_List_UseCase_Fails(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, errors.NewInternalError("some error"))
+
+	result := sut.handler.List(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusInternalServerError)
+}
+
+func Test_Inventory_Should_Execute_GetById_Correctly(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.GetById(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, result.Body.(models.ProductModel).Id, "1")
+}
+
+func Test_Inventory_Should_Execute_GetByType_Correctly(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.GetByType(internalHttp.HttpRequest{
+		Params: map[string]string{"type": "book"},
+	})
+
+	products := result.Body.([]models.ProductModel)
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, products[0].ProductCategory, "book")
+}
+
+func Test_Inventory_Should_Returns_BadRequest_If_GetByType_Has_No_Type(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.GetByType(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Inventory_Should_Returns_Error_If_GetByType_UseCase_Fails(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, errors.NewInternalError("some error"))
+
+	result := sut.handler.GetByType(internalHttp.HttpRequest{
+		Params: map[string]string{"type": "book"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusInternalServerError)
+}
+
+func Test_Inventory_Should_Execute_Update_Correctly(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+		Body:   []byte(`{"amount_in_stock": 0}`),
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, result.Body.(models.ProductModel).Id, "1")
+}
+
+func Test_Inventory_Should_Returns_BadRequest_If_Update_Has_No_Fields(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, nil)
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+		Body:   []byte(`{}`),
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Inventory_Should_Returns_BadRequest_If_Update_Body_Is_Invalid(t *testing.T) {
+	sut := newInventoryHandlerToTest(true, nil)
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+		Body:   []byte(`{"title": ""}`),
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Inventory_Should_Returns_NotFound_If_Updated_Product_Does_Not_Exist(t *testing.T) {
+	sut := newInventoryHandlerToTest(false, errors.NewNotFoundError("product not found"))
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+		Body:   []byte(`{"title": "New Title"}`),
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNotFound)
+}
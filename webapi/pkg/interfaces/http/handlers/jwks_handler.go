@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	nethttp "net/http"
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/usecases"
+	"webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+)
+
+type IJwksHandler interface {
+	Get(httpRequest http.HttpRequest) http.HttpResponse
+}
+
+type jwksHandler struct {
+	logger  interfaces.ILogger
+	useCase usecases.IGetJsonWebKeysUseCase
+}
+
+func (pst jwksHandler) Get(httpRequest http.HttpRequest) http.HttpResponse {
+	keys := pst.useCase.Perform(httpRequest.Ctx)
+
+	return http.Ok(models.ToJsonWebKeySetResponse(keys), nethttp.Header{
+		"Cache-Control": []string{"public, max-age=300"},
+	})
+}
+
+func NewJwksHandler(logger interfaces.ILogger, useCase usecases.IGetJsonWebKeysUseCase) IJwksHandler {
+	return jwksHandler{
+		logger,
+		useCase,
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+	"webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+)
+
+type IMfaHandler interface {
+	Enroll(httpRequest http.HttpRequest) http.HttpResponse
+	Confirm(httpRequest http.HttpRequest) http.HttpResponse
+	Disable(httpRequest http.HttpRequest) http.HttpResponse
+	Verify(httpRequest http.HttpRequest) http.HttpResponse
+}
+
+type mfaHandler struct {
+	logger         interfaces.ILogger
+	enrollUseCase  usecases.IEnrollMfaUseCase
+	confirmUseCase usecases.IConfirmMfaUseCase
+	disableUseCase usecases.IDisableMfaUseCase
+	verifyUseCase  usecases.IVerifyMfaUseCase
+	validator      interfaces.IValidator
+}
+
+func (pst mfaHandler) Enroll(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	result, err := pst.enrollUseCase.Perform(httpRequest.Ctx, session.Id)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Created(models.ToMfaEnrollmentResponse(result), nil)
+}
+
+func (pst mfaHandler) Confirm(httpRequest http.HttpRequest) http.HttpResponse {
+	dto, response, ok := pst.codeDto(httpRequest)
+	if !ok {
+		return response
+	}
+
+	codes, err := pst.confirmUseCase.Perform(httpRequest.Ctx, dto)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.MfaRecoveryCodesResponse{RecoveryCodes: codes}, nil)
+}
+
+func (pst mfaHandler) Disable(httpRequest http.HttpRequest) http.HttpResponse {
+	dto, response, ok := pst.codeDto(httpRequest)
+	if !ok {
+		return response
+	}
+
+	if err := pst.disableUseCase.Perform(httpRequest.Ctx, dto); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func (pst mfaHandler) Verify(httpRequest http.HttpRequest) http.HttpResponse {
+	model := models.MfaChallengeRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	dto := model.ToMfaChallengeDto()
+	dto.ClientIp = httpRequest.ClientIp
+
+	result, err := pst.verifyUseCase.Perform(httpRequest.Ctx, dto)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToSessionResponse(result), nil)
+}
+
+func (pst mfaHandler) codeDto(httpRequest http.HttpRequest) (dtos.MfaCodeDto, http.HttpResponse, bool) {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return dtos.MfaCodeDto{}, http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil), false
+	}
+
+	model := models.MfaCodeRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return dtos.MfaCodeDto{}, http.BadRequest(models.StringToErrorResponse("body is required"), nil), false
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return dtos.MfaCodeDto{}, http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil), false
+	}
+
+	return dtos.MfaCodeDto{
+		UserId:   session.Id,
+		Code:     model.Code,
+		ClientIp: httpRequest.ClientIp,
+	}, http.HttpResponse{}, true
+}
+
+func NewMfaHandler(
+	logger interfaces.ILogger,
+	enrollUseCase usecases.IEnrollMfaUseCase,
+	confirmUseCase usecases.IConfirmMfaUseCase,
+	disableUseCase usecases.IDisableMfaUseCase,
+	verifyUseCase usecases.IVerifyMfaUseCase,
+	validator interfaces.IValidator,
+) IMfaHandler {
+	return mfaHandler{
+		logger,
+		enrollUseCase,
+		confirmUseCase,
+		disableUseCase,
+		verifyUseCase,
+		validator,
+	}
+}
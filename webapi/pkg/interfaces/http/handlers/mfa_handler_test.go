@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	internalHttp "webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mfa_Should_Execute_Enroll_Correctly(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+
+	result := sut.handler.Enroll(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusCreated)
+	assert.IsType(t, result.Body, models.MfaEnrollmentResponse{})
+}
+
+func Test_Mfa_Should_Returns_Unauthorized_If_Enroll_Has_No_Session(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+
+	result := sut.handler.Enroll(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
+
+func Test_Mfa_Should_Return_Recovery_Codes_On_Confirm(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.MfaCodeRequest{Code: "123456"})
+
+	result := sut.handler.Confirm(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Len(t, result.Body.(models.MfaRecoveryCodesResponse).RecoveryCodes, 1)
+}
+
+func Test_Mfa_Should_Returns_BadRequest_If_Confirm_Has_No_Body(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+
+	result := sut.handler.Confirm(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Mfa_Should_Execute_Disable_Correctly(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.MfaCodeRequest{Code: "123456"})
+
+	result := sut.handler.Disable(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_Mfa_Should_Returns_BadRequest_If_Disable_Body_Is_Invalid(t *testing.T) {
+	sut := newMfaHandlerToTest(true, nil)
+	body, _ := json.Marshal(models.MfaCodeRequest{})
+
+	result := sut.handler.Disable(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Mfa_Should_Exchange_Mfa_Token_For_A_Session(t *testing.T) {
+	sut := newMfaHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.MfaChallengeRequest{MfaToken: "mfa token", Code: "123456"})
+
+	result := sut.handler.Verify(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.IsType(t, result.Body, models.SessionResponse{})
+}
+
+func Test_Mfa_Should_Returns_Unauthorized_If_Code_Is_Rejected(t *testing.T) {
+	sut := newMfaHandlerToTest(false, errors.NewUnauthorizeError("Invalid two-factor code"))
+	body, _ := json.Marshal(models.MfaChallengeRequest{MfaToken: "mfa token", Code: "000000"})
+
+	result := sut.handler.Verify(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
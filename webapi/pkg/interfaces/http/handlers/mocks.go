@@ -19,8 +19,11 @@ type userHandlerToTest struct {
 func newUserHandlerToTest(validationFailure bool, useCaseError error) userHandlerToTest {
 	loggerSpy := logger.NewLoggerSpy()
 	useCase := createUserUseCaseSpy{useCaseError}
+	revokeSessionsUseCase := revokeUserSessionsUseCaseSpy{useCaseError}
 	validatorSpy := _validatorSpy{validationFailure}
-	handler := NewUsersHandler(loggerSpy, useCase, validatorSpy)
+	verifyEmailUseCase := verifyEmailUseCaseSpy{useCaseError}
+	requestVerificationUseCase := requestEmailVerificationUseCaseSpy{useCaseError}
+	handler := NewUsersHandler(loggerSpy, useCase, revokeSessionsUseCase, verifyEmailUseCase, requestVerificationUseCase, validatorSpy)
 
 	mockedUser := models.CreateUserRequest{
 		Name:     "Some Name",
@@ -54,6 +57,22 @@ func (pst createUserUseCaseSpy) Perform(ctx context.Context, dto dtos.CreateUser
 	return dtos.CreatedUserDto{}, pst.useCaseError
 }
 
+type verifyEmailUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst verifyEmailUseCaseSpy) Perform(ctx context.Context, token string) error {
+	return pst.useCaseError
+}
+
+type requestEmailVerificationUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst requestEmailVerificationUseCaseSpy) Perform(ctx context.Context, userId int) error {
+	return pst.useCaseError
+}
+
 type sessionHandlerToTest struct {
 	handler    ISessionHandler
 	loggerSpy  interfaces.ILogger
@@ -64,8 +83,10 @@ type sessionHandlerToTest struct {
 func newSessionHandlerToTest(validationFailure bool, useCaseError error) sessionHandlerToTest {
 	loggerSpy := logger.NewLoggerSpy()
 	useCase := sessionUseCaseSpy{useCaseError}
+	refreshUseCase := refreshSessionUseCaseSpy{useCaseError}
+	logoutUseCase := logoutUseCaseSpy{useCaseError}
 	validatorSpy := _validatorSpy{validationFailure}
-	handler := NewSessionHandler(loggerSpy, useCase, validatorSpy)
+	handler := NewSessionHandler(loggerSpy, useCase, refreshUseCase, logoutUseCase, validatorSpy)
 
 	mockedUser := models.CreateUserRequest{
 		Name:     "Some Name",
@@ -83,3 +104,219 @@ type sessionUseCaseSpy struct {
 func (pst sessionUseCaseSpy) Perform(ctx context.Context, dto dtos.SignInDto) (dtos.SessionDto, error) {
 	return dtos.SessionDto{}, pst.useCaseError
 }
+
+type refreshSessionUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst refreshSessionUseCaseSpy) Perform(ctx context.Context, refreshToken string) (dtos.SessionDto, error) {
+	return dtos.SessionDto{}, pst.useCaseError
+}
+
+type logoutUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst logoutUseCaseSpy) Perform(ctx context.Context, session dtos.SessionDto) error {
+	return pst.useCaseError
+}
+
+type revokeUserSessionsUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst revokeUserSessionsUseCaseSpy) Perform(ctx context.Context, userId int) error {
+	return pst.useCaseError
+}
+
+type passwordResetHandlerToTest struct {
+	handler IPasswordResetHandler
+}
+
+func newPasswordResetHandlerToTest(validationFailure bool, useCaseError error) passwordResetHandlerToTest {
+	loggerSpy := logger.NewLoggerSpy()
+	requestUseCase := requestPasswordResetUseCaseSpy{useCaseError}
+	confirmUseCase := confirmPasswordResetUseCaseSpy{useCaseError}
+	validatorSpy := _validatorSpy{validationFailure}
+	handler := NewPasswordResetHandler(loggerSpy, requestUseCase, confirmUseCase, validatorSpy)
+
+	return passwordResetHandlerToTest{handler}
+}
+
+type requestPasswordResetUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst requestPasswordResetUseCaseSpy) Perform(ctx context.Context, email string) error {
+	return pst.useCaseError
+}
+
+type confirmPasswordResetUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst confirmPasswordResetUseCaseSpy) Perform(ctx context.Context, dto dtos.ConfirmPasswordResetDto) error {
+	return pst.useCaseError
+}
+
+type mfaHandlerToTest struct {
+	handler IMfaHandler
+}
+
+func newMfaHandlerToTest(validationFailure bool, useCaseError error) mfaHandlerToTest {
+	loggerSpy := logger.NewLoggerSpy()
+	validatorSpy := _validatorSpy{validationFailure}
+	handler := NewMfaHandler(
+		loggerSpy,
+		enrollMfaUseCaseSpy{useCaseError},
+		confirmMfaUseCaseSpy{useCaseError},
+		disableMfaUseCaseSpy{useCaseError},
+		verifyMfaUseCaseSpy{useCaseError},
+		validatorSpy,
+	)
+
+	return mfaHandlerToTest{handler}
+}
+
+type enrollMfaUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst enrollMfaUseCaseSpy) Perform(ctx context.Context, userId int) (dtos.MfaEnrollmentDto, error) {
+	return dtos.MfaEnrollmentDto{Secret: "SECRET", ProvisioningUri: "otpauth://totp/GoWebApi:some@email.com?secret=SECRET"}, pst.useCaseError
+}
+
+type confirmMfaUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst confirmMfaUseCaseSpy) Perform(ctx context.Context, dto dtos.MfaCodeDto) ([]string, error) {
+	return []string{"aaaa-bbbb-cccc-dddd"}, pst.useCaseError
+}
+
+type disableMfaUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst disableMfaUseCaseSpy) Perform(ctx context.Context, dto dtos.MfaCodeDto) error {
+	return pst.useCaseError
+}
+
+type verifyMfaUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst verifyMfaUseCaseSpy) Perform(ctx context.Context, dto dtos.MfaChallengeDto) (dtos.SessionDto, error) {
+	return dtos.SessionDto{AccessToken: "access token"}, pst.useCaseError
+}
+
+type profileHandlerToTest struct {
+	handler IProfileHandler
+}
+
+func newProfileHandlerToTest(validationFailure bool, useCaseError error) profileHandlerToTest {
+	loggerSpy := logger.NewLoggerSpy()
+	validatorSpy := _validatorSpy{validationFailure}
+	handler := NewProfileHandler(
+		loggerSpy,
+		getUserProfileUseCaseSpy{useCaseError},
+		updateUserProfileUseCaseSpy{useCaseError},
+		changePasswordUseCaseSpy{useCaseError},
+		deleteUserUseCaseSpy{useCaseError},
+		validatorSpy,
+	)
+
+	return profileHandlerToTest{handler}
+}
+
+type getUserProfileUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst getUserProfileUseCaseSpy) Perform(ctx context.Context, userId int) (dtos.UserProfileDto, error) {
+	return dtos.UserProfileDto{Id: userId, Name: "Name", Email: "some@email.com"}, pst.useCaseError
+}
+
+type updateUserProfileUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst updateUserProfileUseCaseSpy) Perform(ctx context.Context, userId int, dto dtos.UpdateUserDto) (dtos.UserProfileDto, error) {
+	return dtos.UserProfileDto{Id: userId, Name: dto.Name, Email: dto.Email}, pst.useCaseError
+}
+
+type changePasswordUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst changePasswordUseCaseSpy) Perform(ctx context.Context, dto dtos.ChangePasswordDto) error {
+	return pst.useCaseError
+}
+
+type deleteUserUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst deleteUserUseCaseSpy) Perform(ctx context.Context, userId int) error {
+	return pst.useCaseError
+}
+
+type inventoryHandlerToTest struct {
+	handler IInventoryHandler
+}
+
+func newInventoryHandlerToTest(validationFailure bool, useCaseError error) inventoryHandlerToTest {
+	loggerSpy := logger.NewLoggerSpy()
+	validatorSpy := _validatorSpy{validationFailure}
+	handler := NewInventoryHandler(
+		loggerSpy,
+		validatorSpy,
+		getProductByIdUseCaseSpy{useCaseError},
+		getProductsUseCaseSpy{useCaseError},
+		getProductsByTypeUseCaseSpy{useCaseError},
+		createProductUseCaseSpy{useCaseError},
+		updateProductUseCaseSpy{useCaseError},
+	)
+
+	return inventoryHandlerToTest{handler}
+}
+
+type getProductByIdUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst getProductByIdUseCaseSpy) Perform(ctx context.Context, id string) (dtos.ProductDto, error) {
+	return dtos.ProductDto{Id: id}, pst.useCaseError
+}
+
+type getProductsUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst getProductsUseCaseSpy) Perform(ctx context.Context, limit, offset int) (dtos.ProductsPageDto, error) {
+	return dtos.ProductsPageDto{Products: []dtos.ProductDto{{Id: "1"}}, Total: 45, Limit: limit, Offset: offset}, pst.useCaseError
+}
+
+type getProductsByTypeUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst getProductsByTypeUseCaseSpy) Perform(ctx context.Context, productType string) ([]dtos.ProductDto, error) {
+	return []dtos.ProductDto{{Id: "1", ProductCategory: productType}}, pst.useCaseError
+}
+
+type createProductUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst createProductUseCaseSpy) Perform(ctx context.Context, dto dtos.ProductDto) (dtos.ProductDto, error) {
+	return dto, pst.useCaseError
+}
+
+type updateProductUseCaseSpy struct {
+	useCaseError error
+}
+
+func (pst updateProductUseCaseSpy) Perform(ctx context.Context, dto dtos.UpdateProductDto) (dtos.ProductDto, error) {
+	return dtos.ProductDto{Id: dto.Id}, pst.useCaseError
+}
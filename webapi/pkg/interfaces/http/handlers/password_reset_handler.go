@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/usecases"
+	"webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+)
+
+type IPasswordResetHandler interface {
+	Request(httpRequest http.HttpRequest) http.HttpResponse
+	Confirm(httpRequest http.HttpRequest) http.HttpResponse
+}
+
+type passwordResetHandler struct {
+	logger         interfaces.ILogger
+	requestUseCase usecases.IRequestPasswordResetUseCase
+	confirmUseCase usecases.IConfirmPasswordResetUseCase
+	validator      interfaces.IValidator
+}
+
+func (pst passwordResetHandler) Request(httpRequest http.HttpRequest) http.HttpResponse {
+	model := models.PasswordResetRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	if err := pst.requestUseCase.Perform(httpRequest.Ctx, model.Email); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func (pst passwordResetHandler) Confirm(httpRequest http.HttpRequest) http.HttpResponse {
+	model := models.ConfirmPasswordResetRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	if err := pst.confirmUseCase.Perform(httpRequest.Ctx, model.ToConfirmPasswordResetDto()); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func NewPasswordResetHandler(
+	logger interfaces.ILogger,
+	requestUseCase usecases.IRequestPasswordResetUseCase,
+	confirmUseCase usecases.IConfirmPasswordResetUseCase,
+	validator interfaces.IValidator,
+) IPasswordResetHandler {
+	return passwordResetHandler{
+		logger,
+		requestUseCase,
+		confirmUseCase,
+		validator,
+	}
+}
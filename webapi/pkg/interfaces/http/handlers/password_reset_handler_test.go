@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"webapi/pkg/app/errors"
+	internalHttp "webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PasswordReset_Should_Execute_Request_Correctly(t *testing.T) {
+	sut := newPasswordResetHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.PasswordResetRequest{Email: "some@email.com"})
+
+	result := sut.handler.Request(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_PasswordReset_Should_Returns_BadRequest_If_Request_Has_No_Body(t *testing.T) {
+	sut := newPasswordResetHandlerToTest(false, nil)
+
+	result := sut.handler.Request(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_PasswordReset_Should_Execute_Confirm_Correctly(t *testing.T) {
+	sut := newPasswordResetHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.ConfirmPasswordResetRequest{Token: "token", Password: "1234567"})
+
+	result := sut.handler.Confirm(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_PasswordReset_Should_Returns_BadRequest_If_Confirm_Body_Is_Invalid(t *testing.T) {
+	sut := newPasswordResetHandlerToTest(true, nil)
+	body, _ := json.Marshal(models.ConfirmPasswordResetRequest{Token: "token"})
+
+	result := sut.handler.Confirm(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_PasswordReset_Should_Returns_BadRequest_If_Token_Is_Invalid(t *testing.T) {
+	sut := newPasswordResetHandlerToTest(false, errors.NewBadRequestError("Invalid or expired reset token"))
+	body, _ := json.Marshal(models.ConfirmPasswordResetRequest{Token: "token", Password: "1234567"})
+
+	result := sut.handler.Confirm(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
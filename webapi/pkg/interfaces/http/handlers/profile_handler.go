@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/dtos"
+	"webapi/pkg/domain/usecases"
+	"webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+)
+
+// IProfileHandler serves the /users/me endpoints. The user is always the one
+// authenticated by the access token, never an id taken from the request.
+type IProfileHandler interface {
+	Get(httpRequest http.HttpRequest) http.HttpResponse
+	Update(httpRequest http.HttpRequest) http.HttpResponse
+	ChangePassword(httpRequest http.HttpRequest) http.HttpResponse
+	Delete(httpRequest http.HttpRequest) http.HttpResponse
+}
+
+type profileHandler struct {
+	logger                interfaces.ILogger
+	getUseCase            usecases.IGetUserProfileUseCase
+	updateUseCase         usecases.IUpdateUserProfileUseCase
+	changePasswordUseCase usecases.IChangePasswordUseCase
+	deleteUseCase         usecases.IDeleteUserUseCase
+	validator             interfaces.IValidator
+}
+
+func (pst profileHandler) Get(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	result, err := pst.getUseCase.Perform(httpRequest.Ctx, session.Id)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToUserProfileResponse(result), nil)
+}
+
+func (pst profileHandler) Update(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	model := models.UpdateUserProfileRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	result, err := pst.updateUseCase.Perform(httpRequest.Ctx, session.Id, model.ToUpdateUserDto())
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToUserProfileResponse(result), nil)
+}
+
+func (pst profileHandler) ChangePassword(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	model := models.ChangePasswordRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	if err := pst.changePasswordUseCase.Perform(httpRequest.Ctx, dtos.ChangePasswordDto{
+		UserId:          session.Id,
+		CurrentPassword: model.CurrentPassword,
+		NewPassword:     model.NewPassword,
+	}); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func (pst profileHandler) Delete(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	if err := pst.deleteUseCase.Perform(httpRequest.Ctx, session.Id); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func NewProfileHandler(
+	logger interfaces.ILogger,
+	getUseCase usecases.IGetUserProfileUseCase,
+	updateUseCase usecases.IUpdateUserProfileUseCase,
+	changePasswordUseCase usecases.IChangePasswordUseCase,
+	deleteUseCase usecases.IDeleteUserUseCase,
+	validator interfaces.IValidator,
+) IProfileHandler {
+	return profileHandler{
+		logger,
+		getUseCase,
+		updateUseCase,
+		changePasswordUseCase,
+		deleteUseCase,
+		validator,
+	}
+}
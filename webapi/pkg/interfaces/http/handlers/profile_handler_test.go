@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
+	internalHttp "webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Profile_Should_Execute_Get_Correctly(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+
+	result := sut.handler.Get(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, result.Body.(models.UserProfileResponse).Id, 1)
+}
+
+func Test_Profile_Should_Returns_Unauthorized_If_Get_Has_No_Session(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+
+	result := sut.handler.Get(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
+
+func Test_Profile_Should_Returns_NotFound_If_User_Was_Deleted(t *testing.T) {
+	sut := newProfileHandlerToTest(false, errors.NewNotFoundError("User not found"))
+
+	result := sut.handler.Get(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNotFound)
+}
+
+func Test_Profile_Should_Execute_Update_Correctly(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.UpdateUserProfileRequest{Name: "New Name", Email: "new@email.com"})
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.Equal(t, result.Body.(models.UserProfileResponse).Email, "new@email.com")
+}
+
+func Test_Profile_Should_Returns_BadRequest_If_Update_Is_Invalid(t *testing.T) {
+	sut := newProfileHandlerToTest(true, nil)
+	body, _ := json.Marshal(models.UpdateUserProfileRequest{})
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Profile_Should_Returns_Conflict_If_Email_Is_Taken(t *testing.T) {
+	sut := newProfileHandlerToTest(false, errors.NewConflictError("Email already registered"))
+	body, _ := json.Marshal(models.UpdateUserProfileRequest{Name: "New Name", Email: "taken@email.com"})
+
+	result := sut.handler.Update(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusConflict)
+}
+
+func Test_Profile_Should_Execute_ChangePassword_Correctly(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+	body, _ := json.Marshal(models.ChangePasswordRequest{CurrentPassword: "current", NewPassword: "new password"})
+
+	result := sut.handler.ChangePassword(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_Profile_Should_Returns_BadRequest_If_ChangePassword_Has_No_Body(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+
+	result := sut.handler.ChangePassword(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Profile_Should_Returns_Unauthorized_If_Current_Password_Is_Wrong(t *testing.T) {
+	sut := newProfileHandlerToTest(false, errors.NewUnauthorizeError("Invalid credentials"))
+	body, _ := json.Marshal(models.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "new password"})
+
+	result := sut.handler.ChangePassword(internalHttp.HttpRequest{
+		Body: body,
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
+
+func Test_Profile_Should_Execute_Delete_Correctly(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+
+	result := sut.handler.Delete(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_Profile_Should_Returns_Unauthorized_If_Delete_Has_No_Session(t *testing.T) {
+	sut := newProfileHandlerToTest(false, nil)
+
+	result := sut.handler.Delete(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
@@ -11,12 +11,16 @@ import (
 
 type ISessionHandler interface {
 	Create(httpRequest http.HttpRequest) http.HttpResponse
+	Refresh(httpRequest http.HttpRequest) http.HttpResponse
+	Delete(httpRequest http.HttpRequest) http.HttpResponse
 }
 
 type sessionHandler struct {
-	logger    interfaces.ILogger
-	useCases  usecases.ISessionUseCase
-	validator interfaces.IValidator
+	logger         interfaces.ILogger
+	useCases       usecases.ISessionUseCase
+	refreshUseCase usecases.IRefreshSessionUseCase
+	logoutUseCase  usecases.ILogoutUseCase
+	validator      interfaces.IValidator
 }
 
 func (pst sessionHandler) Create(httpRequest http.HttpRequest) http.HttpResponse {
@@ -31,18 +35,66 @@ func (pst sessionHandler) Create(httpRequest http.HttpRequest) http.HttpResponse
 		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
 	}
 
-	result, err := pst.useCases.Perform(httpRequest.Ctx, model.ToSignInDto())
+	dto := model.ToSignInDto()
+	dto.ClientIp = httpRequest.ClientIp
+
+	result, err := pst.useCases.Perform(httpRequest.Ctx, dto)
 	if err != nil {
 		return http.ErrorResponseMapper(err, nil)
 	}
 
+	if result.MfaRequired {
+		return http.Ok(models.ToMfaChallengeResponse(result), nil)
+	}
+
 	return http.Ok(models.ToSessionResponse(result), nil)
 }
 
-func NewSessionHandler(logger interfaces.ILogger, useCases usecases.ISessionUseCase, validator interfaces.IValidator) ISessionHandler {
+func (pst sessionHandler) Refresh(httpRequest http.HttpRequest) http.HttpResponse {
+	model := models.RefreshSessionRequest{}
+	if err := json.Unmarshal(httpRequest.Body, &model); err != nil {
+		pst.logger.Error(err.Error())
+		return http.BadRequest(models.StringToErrorResponse("body is required"), nil)
+	}
+
+	if validationErrs := pst.validator.ValidateStruct(model); validationErrs != nil {
+		pst.logger.Error(validationErrs[0].Message)
+		return http.BadRequest(models.StringToErrorResponse(validationErrs[0].Message), nil)
+	}
+
+	result, err := pst.refreshUseCase.Perform(httpRequest.Ctx, model.RefreshToken)
+	if err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.Ok(models.ToSessionResponse(result), nil)
+}
+
+func (pst sessionHandler) Delete(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	if err := pst.logoutUseCase.Perform(httpRequest.Ctx, *session); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func NewSessionHandler(
+	logger interfaces.ILogger,
+	useCases usecases.ISessionUseCase,
+	refreshUseCase usecases.IRefreshSessionUseCase,
+	logoutUseCase usecases.ILogoutUseCase,
+	validator interfaces.IValidator,
+) ISessionHandler {
 	return sessionHandler{
 		logger,
 		useCases,
+		refreshUseCase,
+		logoutUseCase,
 		validator,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
 	internalHttp "webapi/pkg/interfaces/http"
 
 	"github.com/stretchr/testify/assert"
@@ -56,3 +57,53 @@ func Test_Auth_Should_Returns_Http4xx_If_Some_Error_Occur_In_UseCase(t *testing.
 
 	assert.Equal(t, result.StatusCode, http.StatusConflict)
 }
+
+func Test_Auth_Should_Execute_Refresh_Correctly(t *testing.T) {
+	sut := newSessionHandlerToTest(false, nil)
+	body, _ := json.Marshal(map[string]string{"refresh_token": "some token"})
+
+	result := sut.handler.Refresh(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+}
+
+func Test_Auth_Should_Returns_BadRequest_On_Refresh_If_Has_No_Body(t *testing.T) {
+	sut := newSessionHandlerToTest(false, nil)
+
+	result := sut.handler.Refresh(
+		internalHttp.HttpRequest{},
+	)
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_Auth_Should_Returns_Unauthorized_If_Refresh_Token_Is_Rejected(t *testing.T) {
+	sut := newSessionHandlerToTest(false, errors.NewUnauthorizeError("Invalid refresh token"))
+	body, _ := json.Marshal(map[string]string{"refresh_token": "some token"})
+
+	result := sut.handler.Refresh(internalHttp.HttpRequest{
+		Body: body,
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
+
+func Test_Auth_Should_Execute_Logout_Correctly(t *testing.T) {
+	sut := newSessionHandlerToTest(false, nil)
+
+	result := sut.handler.Delete(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1, TokenId: "token id"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_Auth_Should_Returns_Unauthorized_On_Logout_Without_Session(t *testing.T) {
+	sut := newSessionHandlerToTest(false, nil)
+
+	result := sut.handler.Delete(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
 	"webapi/pkg/domain/usecases"
 	"webapi/pkg/interfaces/http"
 	"webapi/pkg/interfaces/http/models"
@@ -11,12 +13,18 @@ import (
 
 type IUsersHandler interface {
 	Create(httpRequest http.HttpRequest) http.HttpResponse
+	RevokeSessions(httpRequest http.HttpRequest) http.HttpResponse
+	Verify(httpRequest http.HttpRequest) http.HttpResponse
+	ResendVerification(httpRequest http.HttpRequest) http.HttpResponse
 }
 
 type usersHandler struct {
-	logger    interfaces.ILogger
-	useCases  usecases.ICreateUserUseCase
-	validator interfaces.IValidator
+	logger                interfaces.ILogger
+	useCases              usecases.ICreateUserUseCase
+	revokeSessionsUseCase usecases.IRevokeUserSessionsUseCase
+	verifyEmailUseCase    usecases.IVerifyEmailUseCase
+	requestVerification   usecases.IRequestEmailVerificationUseCase
+	validator             interfaces.IValidator
 }
 
 func (pst usersHandler) Create(httpRequest http.HttpRequest) http.HttpResponse {
@@ -38,10 +46,68 @@ func (pst usersHandler) Create(httpRequest http.HttpRequest) http.HttpResponse {
 	return http.Created(models.ToCreateUserResponse(result), nil)
 }
 
-func NewUsersHandler(logger interfaces.ILogger, useCases usecases.ICreateUserUseCase, validator interfaces.IValidator) IUsersHandler {
+func (pst usersHandler) RevokeSessions(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	userId, err := strconv.Atoi(httpRequest.Params["id"])
+	if err != nil {
+		return http.BadRequest(models.StringToErrorResponse("id is invalid"), nil)
+	}
+
+	if userId != session.Id && !session.HasPermissions(entities.PermissionSessionsRevoke) {
+		return http.Forbiden(models.StringToErrorResponse("Not allowed to revoke sessions of another user"), nil)
+	}
+
+	if err := pst.revokeSessionsUseCase.Perform(httpRequest.Ctx, userId); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func (pst usersHandler) Verify(httpRequest http.HttpRequest) http.HttpResponse {
+	token := httpRequest.Query["token"]
+	if token == "" {
+		return http.BadRequest(models.StringToErrorResponse("token is required"), nil)
+	}
+
+	if err := pst.verifyEmailUseCase.Perform(httpRequest.Ctx, token); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func (pst usersHandler) ResendVerification(httpRequest http.HttpRequest) http.HttpResponse {
+	session, ok := httpRequest.Session()
+	if !ok {
+		return http.Unauthorized(models.StringToErrorResponse("Invalid token"), nil)
+	}
+
+	if err := pst.requestVerification.Perform(httpRequest.Ctx, session.Id); err != nil {
+		return http.ErrorResponseMapper(err, nil)
+	}
+
+	return http.NoContent(nil)
+}
+
+func NewUsersHandler(
+	logger interfaces.ILogger,
+	useCases usecases.ICreateUserUseCase,
+	revokeSessionsUseCase usecases.IRevokeUserSessionsUseCase,
+	verifyEmailUseCase usecases.IVerifyEmailUseCase,
+	requestVerification usecases.IRequestEmailVerificationUseCase,
+	validator interfaces.IValidator,
+) IUsersHandler {
 	return usersHandler{
 		logger,
 		useCases,
+		revokeSessionsUseCase,
+		verifyEmailUseCase,
+		requestVerification,
 		validator,
 	}
 }
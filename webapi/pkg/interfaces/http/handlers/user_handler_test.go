@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
 	internalHttp "webapi/pkg/interfaces/http"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +59,95 @@ func Test_CrtUser_Should_Returns_Http4xx_If_Some_Error_Occur_In_UseCase(t *testi
 
 	assert.Equal(t, result.StatusCode, http.StatusConflict)
 }
+
+func Test_CrtUser_Should_Revoke_Own_Sessions_Correctly(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.RevokeSessions(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "1"},
+		Auth:   &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_CrtUser_Should_Returns_Forbiden_When_Revoking_Sessions_Of_Another_User(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.RevokeSessions(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "2"},
+		Auth:   &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusForbidden)
+}
+
+func Test_CrtUser_Should_Revoke_Sessions_Of_Another_User_With_Permission(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.RevokeSessions(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "2"},
+		Auth:   &dtos.SessionDto{Id: 1, Permissions: []string{"sessions:revoke"}},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_CrtUser_Should_Returns_BadRequest_When_Revoking_Sessions_With_Invalid_Id(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.RevokeSessions(internalHttp.HttpRequest{
+		Params: map[string]string{"id": "abc"},
+		Auth:   &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_CrtUser_Should_Verify_Email_Correctly(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.Verify(internalHttp.HttpRequest{
+		Query: map[string]string{"token": "some token"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_CrtUser_Should_Returns_BadRequest_When_Verifying_Without_Token(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.Verify(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_CrtUser_Should_Returns_Http4xx_If_Verification_Token_Is_Invalid(t *testing.T) {
+	sut := newUserHandlerToTest(false, errors.NewBadRequestError("Invalid or expired verification token"))
+
+	result := sut.handler.Verify(internalHttp.HttpRequest{
+		Query: map[string]string{"token": "some token"},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+}
+
+func Test_CrtUser_Should_Resend_Verification_Correctly(t *testing.T) {
+	sut := newUserHandlerToTest(false, nil)
+
+	result := sut.handler.ResendVerification(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusNoContent)
+}
+
+func Test_CrtUser_Should_Returns_Conflict_When_Resending_Verification_Of_Verified_Email(t *testing.T) {
+	sut := newUserHandlerToTest(false, errors.NewConflictError("Email already verified"))
+
+	result := sut.handler.ResendVerification(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusConflict)
+}
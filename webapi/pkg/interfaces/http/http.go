@@ -2,8 +2,12 @@ package http
 
 import (
 	"context"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 	"webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
 	"webapi/pkg/interfaces/http/models"
 )
 
@@ -16,11 +20,35 @@ type HttpResponse struct {
 }
 
 type HttpRequest struct {
-	Body    []byte
-	Headers http.Header
-	Params  map[string]string
-	Auth    interface{}
-	Ctx     context.Context
+	Body     []byte
+	Headers  http.Header
+	Params   map[string]string
+	Query    map[string]string
+	Path     string
+	Auth     interface{}
+	ClientIp string
+	Ctx      context.Context
+}
+
+// Session returns the session the auth middleware attached to the request.
+func (pst HttpRequest) Session() (*dtos.SessionDto, bool) {
+	session, ok := pst.Auth.(*dtos.SessionDto)
+	if !ok || session == nil {
+		return nil, false
+	}
+
+	return session, true
+}
+
+// QueryInt reads key from the query string as an integer, falling back when
+// the key is absent.
+func (pst HttpRequest) QueryInt(key string, fallback int) (int, error) {
+	value, ok := pst.Query[key]
+	if !ok || value == "" {
+		return fallback, nil
+	}
+
+	return strconv.Atoi(value)
 }
 
 func Ok(body interface{}, headers http.Header) HttpResponse {
@@ -91,6 +119,15 @@ func Conflict(body models.ErrorResponse, headers http.Header) HttpResponse {
 	}
 }
 
+func TooManyRequests(body models.ErrorResponse, headers http.Header) HttpResponse {
+	body.StatusCode = 429
+	return HttpResponse{
+		StatusCode: 429,
+		Body:       body,
+		Headers:    headers,
+	}
+}
+
 func InternalServerError(body models.ErrorResponse, headers http.Header) HttpResponse {
 	body.StatusCode = 500
 	return HttpResponse{
@@ -100,17 +137,67 @@ func InternalServerError(body models.ErrorResponse, headers http.Header) HttpRes
 	}
 }
 
+func ServiceUnavailable(body models.ErrorResponse, headers http.Header) HttpResponse {
+	body.StatusCode = 503
+	return HttpResponse{
+		StatusCode: 503,
+		Body:       body,
+		Headers:    headers,
+	}
+}
+
+func GatewayTimeout(body models.ErrorResponse, headers http.Header) HttpResponse {
+	body.StatusCode = 504
+	return HttpResponse{
+		StatusCode: 504,
+		Body:       body,
+		Headers:    headers,
+	}
+}
+
 func ErrorResponseMapper(err error, headers http.Header) HttpResponse {
+	body := toErrorResponse(err)
+
 	switch err.(type) {
 	case errors.BadRequestError:
-		return BadRequest(models.ErrorResponse{Message: err.Error()}, headers)
+		return BadRequest(body, headers)
 	case errors.UnauthorizeError:
-		return Unauthorized(models.ErrorResponse{Message: err.Error()}, headers)
+		return Unauthorized(body, headers)
+	case errors.ForbiddenError:
+		return Forbiden(body, headers)
 	case errors.NotFoundError:
-		return NotFound(models.ErrorResponse{Message: err.Error()}, headers)
+		return NotFound(body, headers)
 	case errors.ConflictError:
-		return Conflict(models.ErrorResponse{Message: err.Error()}, headers)
+		return Conflict(body, headers)
+	case errors.TooManyRequestsError:
+		return TooManyRequests(body, withRetryAfter(headers, err.(errors.TooManyRequestsError).RetryAfter))
+	case errors.ServiceUnavailableError:
+		return ServiceUnavailable(body, withRetryAfter(headers, err.(errors.ServiceUnavailableError).RetryAfter))
+	case errors.GatewayTimeoutError:
+		return GatewayTimeout(body, headers)
 	default:
-		return InternalServerError(models.ErrorResponse{Message: err.Error()}, headers)
+		return InternalServerError(body, headers)
+	}
+}
+
+func toErrorResponse(err error) models.ErrorResponse {
+	body := models.ErrorResponse{Message: err.Error()}
+	if detailed, ok := err.(errors.IDetailedError); ok {
+		body.Details = detailed.ErrorDetails()
 	}
+
+	return body
+}
+
+func withRetryAfter(headers http.Header, retryAfter time.Duration) http.Header {
+	if retryAfter <= 0 {
+		return headers
+	}
+
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	return headers
 }
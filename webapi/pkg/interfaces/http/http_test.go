@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 	internalErrors "webapi/pkg/app/errors"
+	"webapi/pkg/domain/dtos"
 	"webapi/pkg/interfaces/http/models"
 
 	"github.com/stretchr/testify/assert"
@@ -75,6 +77,27 @@ func Test_InternalServerErrorFunc_Http_Should_Return_Ok_StatusCode(t *testing.T)
 	assert.IsType(t, result.Body, models.ErrorResponse{})
 }
 
+func Test_TooManyRequestsFunc_Http_Should_Return_Ok_StatusCode(t *testing.T) {
+	result := TooManyRequests(models.ErrorResponse{}, http.Header{})
+
+	assert.Equal(t, result.StatusCode, http.StatusTooManyRequests)
+	assert.IsType(t, result.Body, models.ErrorResponse{})
+}
+
+func Test_ServiceUnavailableFunc_Http_Should_Return_Ok_StatusCode(t *testing.T) {
+	result := ServiceUnavailable(models.ErrorResponse{}, http.Header{})
+
+	assert.Equal(t, result.StatusCode, http.StatusServiceUnavailable)
+	assert.IsType(t, result.Body, models.ErrorResponse{})
+}
+
+func Test_GatewayTimeoutFunc_Http_Should_Return_Ok_StatusCode(t *testing.T) {
+	result := GatewayTimeout(models.ErrorResponse{}, http.Header{})
+
+	assert.Equal(t, result.StatusCode, http.StatusGatewayTimeout)
+	assert.IsType(t, result.Body, models.ErrorResponse{})
+}
+
 func Test_ErrorResponseMapper(t *testing.T) {
 	type inputs struct {
 		err    error
@@ -83,8 +106,12 @@ func Test_ErrorResponseMapper(t *testing.T) {
 	inputsToTest := []inputs{
 		{err: internalErrors.NewBadRequestError(""), status: http.StatusBadRequest},
 		{err: internalErrors.NewUnauthorizeError(""), status: http.StatusUnauthorized},
+		{err: internalErrors.NewForbiddenError(""), status: http.StatusForbidden},
 		{err: internalErrors.NewNotFoundError(""), status: http.StatusNotFound},
 		{err: internalErrors.NewConflictError(""), status: http.StatusConflict},
+		{err: internalErrors.NewTooManyRequestsError("", time.Second), status: http.StatusTooManyRequests},
+		{err: internalErrors.NewServiceUnavailableError("", 0), status: http.StatusServiceUnavailable},
+		{err: internalErrors.NewGatewayTimeoutError(""), status: http.StatusGatewayTimeout},
 		{err: errors.New(""), status: http.StatusInternalServerError},
 	}
 
@@ -94,3 +121,68 @@ func Test_ErrorResponseMapper(t *testing.T) {
 		assert.Equal(t, result.StatusCode, in.status)
 	}
 }
+
+func Test_ErrorResponseMapper_Should_Set_Retry_After_On_TooManyRequests(t *testing.T) {
+	result := ErrorResponseMapper(internalErrors.NewTooManyRequestsError("", time.Millisecond*1500), nil)
+
+	assert.Equal(t, result.StatusCode, http.StatusTooManyRequests)
+	assert.Equal(t, result.Headers.Get("Retry-After"), "2")
+}
+
+func Test_ErrorResponseMapper_Should_Set_Retry_After_On_ServiceUnavailable(t *testing.T) {
+	result := ErrorResponseMapper(internalErrors.NewServiceUnavailableError("", time.Second*30), nil)
+
+	assert.Equal(t, result.StatusCode, http.StatusServiceUnavailable)
+	assert.Equal(t, result.Headers.Get("Retry-After"), "30")
+}
+
+func Test_ErrorResponseMapper_Should_Not_Set_Retry_After_Without_Estimate(t *testing.T) {
+	result := ErrorResponseMapper(internalErrors.NewTooManyRequestsError("", 0), nil)
+
+	assert.Equal(t, result.StatusCode, http.StatusTooManyRequests)
+	assert.Empty(t, result.Headers.Get("Retry-After"))
+}
+
+func Test_ErrorResponseMapper_Should_Keep_Error_Details(t *testing.T) {
+	err := internalErrors.BadRequestError{Message: "invalid product", Details: []string{"title: must not be empty"}}
+
+	result := ErrorResponseMapper(err, http.Header{})
+
+	assert.Equal(t, result.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, result.Body.(models.ErrorResponse).Details, []string{"title: must not be empty"})
+}
+
+func Test_HttpRequest_Session_Should_Return_Authenticated_Session(t *testing.T) {
+	request := HttpRequest{Auth: &dtos.SessionDto{Id: 1}}
+
+	session, ok := request.Session()
+
+	assert.True(t, ok)
+	assert.Equal(t, session.Id, 1)
+}
+
+func Test_HttpRequest_QueryInt_Should_Parse_Value(t *testing.T) {
+	value, err := HttpRequest{Query: map[string]string{"limit": "50"}}.QueryInt("limit", 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, value, 50)
+}
+
+func Test_HttpRequest_QueryInt_Should_Return_Fallback_If_Absent(t *testing.T) {
+	value, err := HttpRequest{}.QueryInt("limit", 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, value, 20)
+}
+
+func Test_HttpRequest_QueryInt_Should_Return_Error_If_Not_A_Number(t *testing.T) {
+	_, err := HttpRequest{Query: map[string]string{"limit": "abc"}}.QueryInt("limit", 20)
+
+	assert.Error(t, err)
+}
+
+func Test_HttpRequest_Session_Should_Return_False_Without_Auth(t *testing.T) {
+	_, ok := HttpRequest{}.Session()
+
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"strings"
+	"webapi/pkg/domain/dtos"
 	"webapi/pkg/domain/usecases"
 	"webapi/pkg/interfaces/http"
 	"webapi/pkg/interfaces/http/models"
@@ -9,6 +10,7 @@ import (
 
 type IAuthMiddleware interface {
 	Perform(httpRequest http.HttpRequest) http.HttpResponse
+	PerformVerified(httpRequest http.HttpRequest) http.HttpResponse
 }
 
 type authMiddleware struct {
@@ -31,6 +33,21 @@ func (pst authMiddleware) Perform(httpRequest http.HttpRequest) http.HttpRespons
 	return http.Ok(&authenticatedUser, httpRequest.Headers)
 }
 
+// PerformVerified authenticates the request like Perform and additionally
+// refuses accounts that have not confirmed their email address yet.
+func (pst authMiddleware) PerformVerified(httpRequest http.HttpRequest) http.HttpResponse {
+	result := pst.Perform(httpRequest)
+	if result.StatusCode != 200 {
+		return result
+	}
+
+	if session, ok := result.Body.(*dtos.SessionDto); !ok || !session.EmailVerified {
+		return http.Forbiden(models.StringToErrorResponse("Email not verified"), httpRequest.Headers)
+	}
+
+	return result
+}
+
 func NewAuthMiddleware(usecase usecases.IValidationTokenUseCase) IAuthMiddleware {
 	return authMiddleware{
 		usecase,
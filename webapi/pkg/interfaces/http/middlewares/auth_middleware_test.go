@@ -56,3 +56,39 @@ func Test_Should_Return_Unauthorized_If_Some_Error_Occur_In_UseCase(t *testing.T
 
 	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
 }
+
+func Test_Should_Allow_Verified_Accounts(t *testing.T) {
+	sut := newVerifiedAuthMiddlewareToTest(true)
+
+	result := sut.middleware.PerformVerified(internalHttp.HttpRequest{
+		Headers: http.Header{
+			"Authorization": []string{"Bearer some_token"},
+		},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+}
+
+func Test_Should_Return_Forbidden_If_Email_Is_Not_Verified(t *testing.T) {
+	sut := newVerifiedAuthMiddlewareToTest(false)
+
+	result := sut.middleware.PerformVerified(internalHttp.HttpRequest{
+		Headers: http.Header{
+			"Authorization": []string{"Bearer some_token"},
+		},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusForbidden)
+}
+
+func Test_Should_Return_Unauthorized_Before_Checking_Verification(t *testing.T) {
+	sut := newAuthMiddlewareToTest(errors.New("some error"))
+
+	result := sut.middleware.PerformVerified(internalHttp.HttpRequest{
+		Headers: http.Header{
+			"Authorization": []string{"Bearer some_token"},
+		},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"webapi/pkg/app/interfaces"
+	"webapi/pkg/interfaces/http"
+	"webapi/pkg/interfaces/http/models"
+
+	"go.uber.org/zap"
+)
+
+type IAuthorizationMiddleware interface {
+	Require(permissions ...string) func(httpRequest http.HttpRequest) http.HttpResponse
+}
+
+type authorizationMiddleware struct {
+	logger interfaces.ILogger
+}
+
+// Require builds a middleware that lets the request through only when the
+// authenticated session holds every permission. It must be chained after
+// IAuthMiddleware.Perform, which attaches the session to the request.
+func (pst authorizationMiddleware) Require(permissions ...string) func(httpRequest http.HttpRequest) http.HttpResponse {
+	return func(httpRequest http.HttpRequest) http.HttpResponse {
+		session, ok := httpRequest.Session()
+		if !ok {
+			return http.Unauthorized(models.StringToErrorResponse("Invalid token"), httpRequest.Headers)
+		}
+
+		if !session.HasPermissions(permissions...) {
+			pst.logger.Warn(
+				"[AuthorizationMiddleware::Require] permission denied",
+				zap.Int("userId", session.Id),
+				zap.Strings("required", permissions),
+			)
+			return http.Forbiden(models.StringToErrorResponse("Permission denied"), httpRequest.Headers)
+		}
+
+		return http.Ok(session, httpRequest.Headers)
+	}
+}
+
+func NewAuthorizationMiddleware(logger interfaces.ILogger) IAuthorizationMiddleware {
+	return authorizationMiddleware{
+		logger,
+	}
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+	"webapi/pkg/domain/dtos"
+	internalHttp "webapi/pkg/interfaces/http"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Should_Authorize_Session_With_Required_Permissions(t *testing.T) {
+	sut := newAuthorizationMiddlewareToTest()
+
+	result := sut.middleware.Require("inventory:write")(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1, Permissions: []string{"inventory:write", "sessions:revoke"}},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusOK)
+	assert.IsType(t, result.Body, &dtos.SessionDto{})
+}
+
+func Test_Should_Return_Forbiden_If_Session_Lacks_A_Permission(t *testing.T) {
+	sut := newAuthorizationMiddlewareToTest()
+
+	result := sut.middleware.Require("inventory:write", "sessions:revoke")(internalHttp.HttpRequest{
+		Auth: &dtos.SessionDto{Id: 1, Permissions: []string{"inventory:write"}},
+	})
+
+	assert.Equal(t, result.StatusCode, http.StatusForbidden)
+}
+
+func Test_Should_Return_Unauthorized_If_Request_Has_No_Session(t *testing.T) {
+	sut := newAuthorizationMiddlewareToTest()
+
+	result := sut.middleware.Require("inventory:write")(internalHttp.HttpRequest{})
+
+	assert.Equal(t, result.StatusCode, http.StatusUnauthorized)
+}
@@ -3,6 +3,7 @@ package middlewares
 import (
 	"context"
 	"webapi/pkg/domain/dtos"
+	"webapi/pkg/infra/logger"
 )
 
 type authMiddlewareToTest struct {
@@ -10,14 +11,29 @@ type authMiddlewareToTest struct {
 }
 
 func newAuthMiddlewareToTest(useCaseError error) authMiddlewareToTest {
-	middleware := NewAuthMiddleware(validateTokenUseCaseSpy{useCaseError})
+	middleware := NewAuthMiddleware(validateTokenUseCaseSpy{useCaseError, false})
+	return authMiddlewareToTest{middleware}
+}
+
+func newVerifiedAuthMiddlewareToTest(emailVerified bool) authMiddlewareToTest {
+	middleware := NewAuthMiddleware(validateTokenUseCaseSpy{nil, emailVerified})
 	return authMiddlewareToTest{middleware}
 }
 
 type validateTokenUseCaseSpy struct {
-	useCaseError error
+	useCaseError  error
+	emailVerified bool
 }
 
 func (pst validateTokenUseCaseSpy) Perform(ctx context.Context, accessToken string) (dtos.SessionDto, error) {
-	return dtos.SessionDto{Id: 1}, pst.useCaseError
+	return dtos.SessionDto{Id: 1, EmailVerified: pst.emailVerified}, pst.useCaseError
+}
+
+type authorizationMiddlewareToTest struct {
+	middleware IAuthorizationMiddleware
+}
+
+func newAuthorizationMiddlewareToTest() authorizationMiddlewareToTest {
+	middleware := NewAuthorizationMiddleware(logger.NewLoggerSpy())
+	return authorizationMiddlewareToTest{middleware}
 }
@@ -19,21 +19,25 @@ func (pst CreateUserRequest) ToCreateUserDto() dtos.CreateUserDto {
 }
 
 type CreateUserResponse struct {
-	Id          int    `json:"id"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
-	AccessToken string `json:"access_token"`
-	Kind        string `json:"kind"`
-	ExpireIn    string `json:"expire_in"`
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	AccessToken     string `json:"access_token"`
+	Kind            string `json:"kind"`
+	ExpireIn        string `json:"expire_in"`
+	RefreshToken    string `json:"refresh_token"`
+	RefreshExpireIn string `json:"refresh_expire_in"`
 }
 
 func ToCreateUserResponse(user dtos.CreatedUserDto) CreateUserResponse {
 	return CreateUserResponse{
-		Id:          user.Id,
-		Name:        user.Name,
-		Email:       user.Email,
-		AccessToken: user.AccessToken,
-		Kind:        user.Kind,
-		ExpireIn:    user.ExpireIn.String(),
+		Id:              user.Id,
+		Name:            user.Name,
+		Email:           user.Email,
+		AccessToken:     user.AccessToken,
+		Kind:            user.Kind,
+		ExpireIn:        user.ExpireIn.String(),
+		RefreshToken:    user.RefreshToken,
+		RefreshExpireIn: user.RefreshExpireIn.String(),
 	}
 }
@@ -1,8 +1,9 @@
 package models
 
 type ErrorResponse struct {
-	StatusCode int    `json:"status_code"`
-	Message    string `json:"message"`
+	StatusCode int      `json:"status_code"`
+	Message    string   `json:"message"`
+	Details    []string `json:"details,omitempty"`
 }
 
 func StringToErrorResponse(message string) ErrorResponse {
@@ -0,0 +1,35 @@
+package models
+
+import "webapi/pkg/domain/dtos"
+
+type JsonWebKeyModel struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JsonWebKeySetResponse struct {
+	Keys []JsonWebKeyModel `json:"keys"`
+}
+
+func ToJsonWebKeySetResponse(keys []dtos.JsonWebKeyDto) JsonWebKeySetResponse {
+	response := JsonWebKeySetResponse{
+		Keys: make([]JsonWebKeyModel, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		response.Keys = append(response.Keys, JsonWebKeyModel{
+			Kid: key.Kid,
+			Kty: key.Kty,
+			Alg: key.Alg,
+			Use: key.Use,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+
+	return response
+}
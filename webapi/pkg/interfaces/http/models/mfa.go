@@ -0,0 +1,49 @@
+package models
+
+import "webapi/pkg/domain/dtos"
+
+type MfaCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MfaChallengeRequest struct {
+	MfaToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+func (pst MfaChallengeRequest) ToMfaChallengeDto() dtos.MfaChallengeDto {
+	return dtos.MfaChallengeDto{
+		MfaToken: pst.MfaToken,
+		Code:     pst.Code,
+	}
+}
+
+type MfaEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"otpauth_uri"`
+}
+
+func ToMfaEnrollmentResponse(dto dtos.MfaEnrollmentDto) MfaEnrollmentResponse {
+	return MfaEnrollmentResponse{
+		Secret:          dto.Secret,
+		ProvisioningUri: dto.ProvisioningUri,
+	}
+}
+
+type MfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MfaChallengeResponse struct {
+	MfaRequired bool   `json:"mfa_required"`
+	MfaToken    string `json:"mfa_token"`
+	ExpiredIn   string `json:"expired_in"`
+}
+
+func ToMfaChallengeResponse(dto dtos.SessionDto) MfaChallengeResponse {
+	return MfaChallengeResponse{
+		MfaRequired: dto.MfaRequired,
+		MfaToken:    dto.MfaToken,
+		ExpiredIn:   dto.ExpireIn.Format(("2006-01-02 15:04:05")),
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import "fmt"
+
+type PageLinksModel struct {
+	Next *string `json:"next"`
+	Prev *string `json:"prev"`
+}
+
+type PageModel struct {
+	Items  interface{}    `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Links  PageLinksModel `json:"links"`
+}
+
+// PageQueryModel keeps Offset plus Limit within the uint32 the inventory takes.
+type PageQueryModel struct {
+	Limit  int `validate:"min=1,max=100"`
+	Offset int `validate:"min=0,max=4294967195"`
+}
+
+// ToPageResponse wraps items in the page envelope. Links point at path with the
+// same limit and are null when there is no page in that direction.
+func ToPageResponse(items interface{}, path string, total, limit, offset int) PageModel {
+	links := PageLinksModel{}
+
+	if offset+limit < total {
+		next := pageLink(path, limit, offset+limit)
+		links.Next = &next
+	}
+
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prev := pageLink(path, limit, prevOffset)
+		links.Prev = &prev
+	}
+
+	return PageModel{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+		Links:  links,
+	}
+}
+
+func pageLink(path string, limit, offset int) string {
+	return fmt.Sprintf("%s?limit=%d&offset=%d", path, limit, offset)
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"webapi/pkg/infra/validator"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PageQueryModel_Should_Keep_The_Last_Item_Within_Uint32(t *testing.T) {
+	sut := validator.NewValidator()
+
+	last := sut.ValidateStruct(PageQueryModel{Limit: 100, Offset: math.MaxUint32 - 100})
+	beyond := sut.ValidateStruct(PageQueryModel{Limit: 100, Offset: math.MaxUint32 - 99})
+
+	assert.Nil(t, last)
+	assert.NotNil(t, beyond)
+}
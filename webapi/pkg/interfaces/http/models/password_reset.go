@@ -0,0 +1,19 @@
+package models
+
+import "webapi/pkg/domain/dtos"
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+func (pst ConfirmPasswordResetRequest) ToConfirmPasswordResetDto() dtos.ConfirmPasswordResetDto {
+	return dtos.ConfirmPasswordResetDto{
+		Token:    pst.Token,
+		Password: pst.Password,
+	}
+}
@@ -40,6 +40,28 @@ func (pst CreateProductModel) ToProductDto() dtos.ProductDto {
 	}
 }
 
+// UpdateProductModel only carries the fields the client wants to change.
+type UpdateProductModel struct {
+	Tag           *string `json:"tag" validate:"omitempty,min=1"`
+	Title         *string `json:"title" validate:"omitempty,min=1"`
+	Subtitle      *string `json:"subtitle" validate:"omitempty,min=1"`
+	AmountInStock *int    `json:"amount_in_stock" validate:"omitempty,min=0"`
+}
+
+func (pst UpdateProductModel) IsEmpty() bool {
+	return pst.Tag == nil && pst.Title == nil && pst.Subtitle == nil && pst.AmountInStock == nil
+}
+
+func (pst UpdateProductModel) ToUpdateProductDto(id string) dtos.UpdateProductDto {
+	return dtos.UpdateProductDto{
+		Id:            id,
+		Tag:           pst.Tag,
+		Title:         pst.Title,
+		Subtitle:      pst.Subtitle,
+		AmountInStock: pst.AmountInStock,
+	}
+}
+
 func ToProductResponse(dto dtos.ProductDto) ProductModel {
 	return ProductModel{
 		Id:              dto.Id,
@@ -55,3 +77,16 @@ func ToProductResponse(dto dtos.ProductDto) ProductModel {
 		UpdatedAt:       dto.UpdatedAt,
 	}
 }
+
+func ToProductsResponse(products []dtos.ProductDto) []ProductModel {
+	models := make([]ProductModel, 0, len(products))
+	for _, product := range products {
+		models = append(models, ToProductResponse(product))
+	}
+
+	return models
+}
+
+func ToProductsPageResponse(dto dtos.ProductsPageDto, path string) PageModel {
+	return ToPageResponse(ToProductsResponse(dto.Products), path, dto.Total, dto.Limit, dto.Offset)
+}
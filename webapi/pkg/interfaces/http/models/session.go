@@ -14,16 +14,24 @@ func (pst SignInRequest) ToSignInDto() dtos.SignInDto {
 	}
 }
 
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 type SessionResponse struct {
-	AccessToken string `json:"access_token"`
-	Kind        string `json:"kind"`
-	ExpiredIn   string `json:"expired_in"`
+	AccessToken      string `json:"access_token"`
+	Kind             string `json:"kind"`
+	ExpiredIn        string `json:"expired_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiredIn string `json:"refresh_expired_in"`
 }
 
 func ToSessionResponse(dto dtos.SessionDto) SessionResponse {
 	return SessionResponse{
-		AccessToken: dto.AccessToken,
-		Kind:        dto.Kind,
-		ExpiredIn:   dto.ExpireIn.Format(("2006-01-02 15:04:05")),
+		AccessToken:      dto.AccessToken,
+		Kind:             dto.Kind,
+		ExpiredIn:        dto.ExpireIn.Format(("2006-01-02 15:04:05")),
+		RefreshToken:     dto.RefreshToken,
+		RefreshExpiredIn: dto.RefreshExpireIn.Format(("2006-01-02 15:04:05")),
 	}
 }
@@ -0,0 +1,40 @@
+package models
+
+import "webapi/pkg/domain/dtos"
+
+type UpdateUserProfileRequest struct {
+	Name  string `json:"name" validate:"required,min=4"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func (pst UpdateUserProfileRequest) ToUpdateUserDto() dtos.UpdateUserDto {
+	return dtos.UpdateUserDto{
+		Name:  pst.Name,
+		Email: pst.Email,
+	}
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+}
+
+type UserProfileResponse struct {
+	Id            int    `json:"id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+func ToUserProfileResponse(dto dtos.UserProfileDto) UserProfileResponse {
+	return UserProfileResponse{
+		Id:            dto.Id,
+		Name:          dto.Name,
+		Email:         dto.Email,
+		EmailVerified: dto.EmailVerified,
+		CreatedAt:     dto.CreatedAt.Format(("2006-01-02 15:04:05")),
+		UpdatedAt:     dto.UpdatedAt.Format(("2006-01-02 15:04:05")),
+	}
+}
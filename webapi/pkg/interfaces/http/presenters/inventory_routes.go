@@ -2,6 +2,7 @@ package presenters
 
 import (
 	"webapi/pkg/app/interfaces"
+	"webapi/pkg/domain/entities"
 	adapter "webapi/pkg/infra/adapters"
 	server "webapi/pkg/infra/http_server"
 	"webapi/pkg/interfaces/http/handlers"
@@ -13,12 +14,20 @@ type IInventoryRoutes interface {
 }
 
 type inventoryRoutes struct {
-	handlers    handlers.IInventoryHandler
-	middlewares middlewares.IAuthMiddleware
-	logger      interfaces.ILogger
+	handlers      handlers.IInventoryHandler
+	middlewares   middlewares.IAuthMiddleware
+	authorization middlewares.IAuthorizationMiddleware
+	logger        interfaces.ILogger
 }
 
 func (pst inventoryRoutes) Register(httpServer server.IHttpServer) {
+	httpServer.RegistreRoute(
+		"GET",
+		"/api/v1/inventory",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.List, pst.logger),
+	)
+
 	httpServer.RegistreRoute(
 		"GET",
 		"/api/v1/inventory/:id",
@@ -26,18 +35,40 @@ func (pst inventoryRoutes) Register(httpServer server.IHttpServer) {
 		adapter.HandlerAdapt(pst.handlers.GetById, pst.logger),
 	)
 
+	httpServer.RegistreRoute(
+		"GET",
+		"/api/v1/inventory/categories/:type",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.GetByType, pst.logger),
+	)
+
 	httpServer.RegistreRoute(
 		"POST",
 		"/api/v1/inventory/",
 		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.MiddlewareAdapt(pst.authorization.Require(entities.PermissionInventoryWrite), pst.logger),
 		adapter.HandlerAdapt(pst.handlers.CreateProduct, pst.logger),
 	)
+
+	httpServer.RegistreRoute(
+		"PUT",
+		"/api/v1/inventory/:id",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.MiddlewareAdapt(pst.authorization.Require(entities.PermissionInventoryWrite), pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Update, pst.logger),
+	)
 }
 
-func NewInventoryRoutes(logger interfaces.ILogger, middlewares middlewares.IAuthMiddleware, handlers handlers.IInventoryHandler) IInventoryRoutes {
+func NewInventoryRoutes(
+	logger interfaces.ILogger,
+	middlewares middlewares.IAuthMiddleware,
+	authorization middlewares.IAuthorizationMiddleware,
+	handlers handlers.IInventoryHandler,
+) IInventoryRoutes {
 	return inventoryRoutes{
 		handlers,
 		middlewares,
+		authorization,
 		logger,
 	}
 }
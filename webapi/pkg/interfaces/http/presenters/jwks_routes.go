@@ -0,0 +1,28 @@
+package presenters
+
+import (
+	"webapi/pkg/app/interfaces"
+	adapter "webapi/pkg/infra/adapters"
+	server "webapi/pkg/infra/http_server"
+	"webapi/pkg/interfaces/http/handlers"
+)
+
+type IJwksRoutes interface {
+	Register(httpServer server.IHttpServer)
+}
+
+type jwksRoutes struct {
+	handlers handlers.IJwksHandler
+	logger   interfaces.ILogger
+}
+
+func (pst jwksRoutes) Register(httpServer server.IHttpServer) {
+	httpServer.RegistreRoute("GET", "/.well-known/jwks.json", adapter.HandlerAdapt(pst.handlers.Get, pst.logger))
+}
+
+func NewJwksRoutes(logger interfaces.ILogger, handlers handlers.IJwksHandler) IJwksRoutes {
+	return jwksRoutes{
+		handlers,
+		logger,
+	}
+}
@@ -0,0 +1,52 @@
+package presenters
+
+import (
+	"webapi/pkg/app/interfaces"
+	adapter "webapi/pkg/infra/adapters"
+	server "webapi/pkg/infra/http_server"
+	"webapi/pkg/interfaces/http/handlers"
+	"webapi/pkg/interfaces/http/middlewares"
+)
+
+type IMfaRoutes interface {
+	Register(httpServer server.IHttpServer)
+}
+
+type mfaRoutes struct {
+	handlers    handlers.IMfaHandler
+	middlewares middlewares.IAuthMiddleware
+	logger      interfaces.ILogger
+}
+
+func (pst mfaRoutes) Register(httpServer server.IHttpServer) {
+	httpServer.RegistreRoute("POST", "/api/v1/auth/mfa", adapter.HandlerAdapt(pst.handlers.Verify, pst.logger))
+
+	httpServer.RegistreRoute(
+		"POST",
+		"/api/v1/auth/mfa/enroll",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Enroll, pst.logger),
+	)
+
+	httpServer.RegistreRoute(
+		"POST",
+		"/api/v1/auth/mfa/confirm",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Confirm, pst.logger),
+	)
+
+	httpServer.RegistreRoute(
+		"POST",
+		"/api/v1/auth/mfa/disable",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Disable, pst.logger),
+	)
+}
+
+func NewMfaRoutes(logger interfaces.ILogger, middlewares middlewares.IAuthMiddleware, handlers handlers.IMfaHandler) IMfaRoutes {
+	return mfaRoutes{
+		handlers,
+		middlewares,
+		logger,
+	}
+}
@@ -0,0 +1,29 @@
+package presenters
+
+import (
+	"webapi/pkg/app/interfaces"
+	adapter "webapi/pkg/infra/adapters"
+	server "webapi/pkg/infra/http_server"
+	"webapi/pkg/interfaces/http/handlers"
+)
+
+type IPasswordResetRoutes interface {
+	Register(httpServer server.IHttpServer)
+}
+
+type passwordResetRoutes struct {
+	handlers handlers.IPasswordResetHandler
+	logger   interfaces.ILogger
+}
+
+func (pst passwordResetRoutes) Register(httpServer server.IHttpServer) {
+	httpServer.RegistreRoute("POST", "/api/v1/auth/password-reset", adapter.HandlerAdapt(pst.handlers.Request, pst.logger))
+	httpServer.RegistreRoute("POST", "/api/v1/auth/password-reset/confirm", adapter.HandlerAdapt(pst.handlers.Confirm, pst.logger))
+}
+
+func NewPasswordResetRoutes(logger interfaces.ILogger, handlers handlers.IPasswordResetHandler) IPasswordResetRoutes {
+	return passwordResetRoutes{
+		handlers,
+		logger,
+	}
+}
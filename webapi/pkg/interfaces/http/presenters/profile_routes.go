@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"webapi/pkg/app/interfaces"
+	adapter "webapi/pkg/infra/adapters"
+	server "webapi/pkg/infra/http_server"
+	"webapi/pkg/interfaces/http/handlers"
+	"webapi/pkg/interfaces/http/middlewares"
+)
+
+type IProfileRoutes interface {
+	Register(httpServer server.IHttpServer)
+}
+
+type profileRoutes struct {
+	handlers    handlers.IProfileHandler
+	middlewares middlewares.IAuthMiddleware
+	logger      interfaces.ILogger
+}
+
+func (pst profileRoutes) Register(httpServer server.IHttpServer) {
+	httpServer.RegistreRoute(
+		"GET",
+		"/api/v1/users/me",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Get, pst.logger),
+	)
+
+	httpServer.RegistreRoute(
+		"PUT",
+		"/api/v1/users/me",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Update, pst.logger),
+	)
+
+	httpServer.RegistreRoute(
+		"DELETE",
+		"/api/v1/users/me",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Delete, pst.logger),
+	)
+
+	httpServer.RegistreRoute(
+		"POST",
+		"/api/v1/users/me/password",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.ChangePassword, pst.logger),
+	)
+}
+
+func NewProfileRoutes(logger interfaces.ILogger, middlewares middlewares.IAuthMiddleware, handlers handlers.IProfileHandler) IProfileRoutes {
+	return profileRoutes{
+		handlers,
+		middlewares,
+		logger,
+	}
+}
@@ -22,7 +22,7 @@ func (pst purchaseRoutes) Register(httpServer server.IHttpServer) {
 	httpServer.RegistreRoute(
 		"POST",
 		"/api/v1/purchase",
-		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.MiddlewareAdapt(pst.middlewares.PerformVerified, pst.logger),
 		adapter.HandlerAdapt(pst.handlers.Create, pst.logger),
 	)
 }
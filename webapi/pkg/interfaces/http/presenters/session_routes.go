@@ -5,6 +5,7 @@ import (
 	adapter "webapi/pkg/infra/adapters"
 	server "webapi/pkg/infra/http_server"
 	"webapi/pkg/interfaces/http/handlers"
+	"webapi/pkg/interfaces/http/middlewares"
 )
 
 type ISessionRoutes interface {
@@ -12,17 +13,27 @@ type ISessionRoutes interface {
 }
 
 type sessionRoutes struct {
-	handlers handlers.IUsersHandler
-	logger   interfaces.ILogger
+	handlers    handlers.ISessionHandler
+	middlewares middlewares.IAuthMiddleware
+	logger      interfaces.ILogger
 }
 
 func (pst sessionRoutes) Register(httpServer server.IHttpServer) {
 	httpServer.RegistreRoute("POST", "/api/v1/auth", adapter.HandlerAdapt(pst.handlers.Create, pst.logger))
+	httpServer.RegistreRoute("POST", "/api/v1/auth/refresh", adapter.HandlerAdapt(pst.handlers.Refresh, pst.logger))
+
+	httpServer.RegistreRoute(
+		"DELETE",
+		"/api/v1/auth",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.Delete, pst.logger),
+	)
 }
 
-func NewSessionRoutes(logger interfaces.ILogger, handlers handlers.ISessionHandler) ISessionRoutes {
+func NewSessionRoutes(logger interfaces.ILogger, middlewares middlewares.IAuthMiddleware, handlers handlers.ISessionHandler) ISessionRoutes {
 	return sessionRoutes{
 		handlers,
+		middlewares,
 		logger,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	adapter "webapi/pkg/infra/adapters"
 	server "webapi/pkg/infra/http_server"
 	"webapi/pkg/interfaces/http/handlers"
+	"webapi/pkg/interfaces/http/middlewares"
 )
 
 type IUsersRoutes interface {
@@ -12,17 +13,35 @@ type IUsersRoutes interface {
 }
 
 type usersRoutes struct {
-	handlers handlers.IUsersHandler
-	logger   interfaces.ILogger
+	handlers    handlers.IUsersHandler
+	middlewares middlewares.IAuthMiddleware
+	logger      interfaces.ILogger
 }
 
 func (pst usersRoutes) Register(httpServer server.IHttpServer) {
 	httpServer.RegistreRoute("POST", "/api/v1/users", adapter.HandlerAdapt(pst.handlers.Create, pst.logger))
+
+	httpServer.RegistreRoute(
+		"DELETE",
+		"/api/v1/users/:id/sessions",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.RevokeSessions, pst.logger),
+	)
+
+	httpServer.RegistreRoute("GET", "/api/v1/users/verify", adapter.HandlerAdapt(pst.handlers.Verify, pst.logger))
+
+	httpServer.RegistreRoute(
+		"POST",
+		"/api/v1/users/verify/resend",
+		adapter.MiddlewareAdapt(pst.middlewares.Perform, pst.logger),
+		adapter.HandlerAdapt(pst.handlers.ResendVerification, pst.logger),
+	)
 }
 
-func NewUsersRoutes(logger interfaces.ILogger, handlers handlers.IUsersHandler) IUsersRoutes {
+func NewUsersRoutes(logger interfaces.ILogger, middlewares middlewares.IAuthMiddleware, handlers handlers.IUsersHandler) IUsersRoutes {
 	return usersRoutes{
 		handlers,
+		middlewares,
 		logger,
 	}
 }